@@ -9,6 +9,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	gogrpc "github.com/ralvarezdev/go-grpc"
 	gogrpcmd "github.com/ralvarezdev/go-grpc/metadata"
 )
 
@@ -17,7 +18,8 @@ type (
 	Interceptor struct {
 		logger        *slog.Logger
 		apiKey        string
-		interceptions map[string]struct{}
+		interceptions *gogrpc.MethodMatcher[struct{}]
+		tokenOptions  *gogrpcmd.TokenOptions
 	}
 )
 
@@ -25,8 +27,10 @@ type (
 //
 // Parameters:
 //
-//   - methodsToIntercept: a slice of method names to intercept
+//   - interceptions: the method matcher to determine which methods to intercept
 //   - apiKey: the API key to use for authentication
+//   - tokenOptions: the metadata key and scheme of the API key (optional, if nil it is set as a bearer token in the
+//     authorization metadata)
 //   - logger: the logger to use for logging
 //
 // Returns:
@@ -34,12 +38,13 @@ type (
 //   - *Interceptor: the interceptor
 //   - error: an error if the interceptions map is nil
 func NewInterceptor(
-	methodsToIntercept []string,
+	interceptions *gogrpc.MethodMatcher[struct{}],
 	apiKey string,
+	tokenOptions *gogrpcmd.TokenOptions,
 	logger *slog.Logger,
 ) (*Interceptor, error) {
 	// Check if the gRPC interceptions is nil
-	if methodsToIntercept == nil {
+	if interceptions == nil {
 		return nil, goapikeygrpc.ErrNilGRPCInterceptions
 	}
 
@@ -48,12 +53,6 @@ func NewInterceptor(
 		return nil, ErrEmptyAPIKey
 	}
 
-	// Create a map of methods to intercept for efficient lookup
-	interceptions := make(map[string]struct{})
-	for _, method := range methodsToIntercept {
-		interceptions[method] = struct{}{}
-	}
-
 	if logger != nil {
 		logger = logger.With(
 			slog.String(
@@ -66,6 +65,7 @@ func NewInterceptor(
 	return &Interceptor{
 		interceptions: interceptions,
 		apiKey:        apiKey,
+		tokenOptions:  tokenOptions,
 		logger:        logger,
 	}, nil
 }
@@ -85,7 +85,7 @@ func (i Interceptor) Authenticate() grpc.UnaryClientInterceptor {
 		opts ...grpc.CallOption,
 	) error {
 		// Check if the method should be intercepted
-		_, ok := i.interceptions[method]
+		_, ok := i.interceptions.Match(method)
 
 		// Invoke the original invoker
 		if !ok {
@@ -93,8 +93,10 @@ func (i Interceptor) Authenticate() grpc.UnaryClientInterceptor {
 		}
 
 		// Set context metadata for the gRPC client with the API key
-		ctx, err := gogrpcmd.SetOutgoingCtxMetadataAuthorizationToken(
+		ctx, err := gogrpcmd.SetOutgoingCtxMetadataToken(
 			ctx,
+			i.tokenOptions.GetKey(),
+			i.tokenOptions.GetScheme(),
 			i.apiKey,
 		)
 		if err != nil {
@@ -9,13 +9,15 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	gogrpc "github.com/ralvarezdev/go-grpc"
 	gogrpcmd "github.com/ralvarezdev/go-grpc/metadata"
 )
 
 type (
 	// Interceptor is the interceptor for the authentication
 	Interceptor struct {
-		interceptions map[string]struct{}
+		interceptions *gogrpc.MethodMatcher[struct{}]
+		tokenOptions  *gogrpcmd.TokenOptions
 		logger        *slog.Logger
 	}
 )
@@ -24,7 +26,9 @@ type (
 //
 // Parameters:
 //
-//   - methodsToIntercept: a slice of method names to intercept
+//   - interceptions: the method matcher to determine which methods to intercept
+//   - tokenOptions: the metadata key and scheme of the API key (optional, if nil it is expected as a bearer token in
+//     the authorization metadata)
 //   - logger: the logger to use for logging
 //
 // Returns:
@@ -32,20 +36,15 @@ type (
 //   - *Interceptor: the interceptor
 //   - error: an error if the interceptions map is nil
 func NewInterceptor(
-	methodsToIntercept []string,
+	interceptions *gogrpc.MethodMatcher[struct{}],
+	tokenOptions *gogrpcmd.TokenOptions,
 	logger *slog.Logger,
 ) (*Interceptor, error) {
 	// Check if the gRPC interceptions is nil
-	if methodsToIntercept == nil {
+	if interceptions == nil {
 		return nil, goapikeygrpc.ErrNilGRPCInterceptions
 	}
 
-	// Create a map of methods to intercept for efficient lookup
-	interceptions := make(map[string]struct{})
-	for _, method := range methodsToIntercept {
-		interceptions[method] = struct{}{}
-	}
-
 	if logger != nil {
 		logger = logger.With(
 			slog.String(
@@ -57,6 +56,7 @@ func NewInterceptor(
 
 	return &Interceptor{
 		interceptions: interceptions,
+		tokenOptions:  tokenOptions,
 		logger:        logger,
 	}, nil
 }
@@ -77,13 +77,15 @@ func (i Interceptor) Verify() grpc.UnaryClientInterceptor {
 		opts ...grpc.CallOption,
 	) error {
 		// Check if the method should be intercepted
-		_, ok := i.interceptions[method]
+		_, ok := i.interceptions.Match(method)
 
 		// If the method is intercepted, verify it has the authorization metadata
 		if ok {
 			// Try to get the authorization metadata from the context
-			_, err := gogrpcmd.GetOutgoingCtxMetadataAuthorizationToken(
+			_, err := gogrpcmd.GetOutgoingCtxMetadataToken(
 				ctx,
+				i.tokenOptions.GetKey(),
+				i.tokenOptions.GetScheme(),
 			)
 			if err != nil {
 				if i.logger != nil {
@@ -17,7 +17,7 @@ import (
 type (
 	// Interceptor is the interceptor for the authentication
 	Interceptor struct {
-		interceptions map[string]*gojwttoken.Token
+		interceptions *gogrpc.MethodMatcher[*gojwttoken.Token]
 		logger        *slog.Logger
 	}
 )
@@ -34,7 +34,7 @@ type (
 //   - *Interceptor: the interceptor
 //   - error: an error if the gRPC interceptions is nil or any other error occurs
 func NewInterceptor(
-	interceptions map[string]*gojwttoken.Token,
+	interceptions *gogrpc.MethodMatcher[*gojwttoken.Token],
 	logger *slog.Logger,
 ) (*Interceptor, error) {
 	// Check if the gRPC interceptions is nil
@@ -73,7 +73,7 @@ func (i Interceptor) Verify() grpc.UnaryClientInterceptor {
 		opts ...grpc.CallOption,
 	) error {
 		// Check if the method should be intercepted, if so, verify the authorization metadata is set
-		interception, ok := i.interceptions[method]
+		interception, ok := i.interceptions.Match(method)
 		if !ok {
 			// Log the error and return an internal server error
 			if i.logger != nil {
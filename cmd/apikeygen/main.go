@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	gogrpcapikeyhashed "github.com/ralvarezdev/go-grpc/server/interceptor/auth/apikey/hashed"
+)
+
+// main generates a new API key and prints it with its salted hash, to be added to the hashed API keys file
+func main() {
+	length := flag.Int(
+		"length",
+		gogrpcapikeyhashed.DefaultAPIKeyLength,
+		"Length in bytes of the generated API key",
+	)
+	apiKey := flag.String(
+		"key",
+		"",
+		"Existing API key to hash instead of generating a new one",
+	)
+	flag.Parse()
+
+	// Generate the API key if none was given
+	key := *apiKey
+	if key == "" {
+		var err error
+		key, err = gogrpcapikeyhashed.GenerateAPIKey(*length)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to generate API key: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Hash the API key
+	hash, err := gogrpcapikeyhashed.HashAPIKey(key)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to hash API key: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("API key: %s\n", key)
+	fmt.Printf("Hash:    %s\n", hash)
+}
@@ -15,4 +15,15 @@ const (
 
 	// GCloudAuthorizationMetadataKey is the key of the authorization metadata
 	GCloudAuthorizationMetadataKey = "x-serverless-authorization"
+
+	// APIKeyMetadataKey is the dedicated key used for API keys in metadata
+	APIKeyMetadataKey = "x-api-key"
+)
+
+const (
+	// WildcardMethodPattern is the wildcard used in method patterns
+	WildcardMethodPattern = "*"
+
+	// ExclusionMethodPatternPrefix is the prefix used to exclude a method pattern
+	ExclusionMethodPatternPrefix = "!"
 )
@@ -2,6 +2,7 @@ package gogrpc
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 )
 
@@ -10,5 +11,20 @@ var (
 )
 
 var (
-	ErrNilInterceptions = errors.New("grpc interceptions map cannot be nil")
+	ErrNilInterceptions     = errors.New("grpc interceptions map cannot be nil")
+	ErrInvalidMethodPattern = errors.New("invalid method pattern")
+	ErrMethodNotIntercepted = errors.New("method is not intercepted")
 )
+
+// NewInvalidMethodPatternError creates a new invalid method pattern error
+//
+// Parameters:
+//
+//   - pattern: the invalid method pattern
+//
+// Returns:
+//
+//   - error: the invalid method pattern error
+func NewInvalidMethodPatternError(pattern string) error {
+	return fmt.Errorf("%w: %q", ErrInvalidMethodPattern, pattern)
+}
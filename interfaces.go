@@ -1,6 +1,8 @@
 package gogrpc
 
 import (
+	"time"
+
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 )
 
@@ -13,4 +15,27 @@ type (
 		NewSingleBadRequest(field, description string) *errdetails.BadRequest
 		NewStructSingleFieldBadRequest(structExample any, field, description string) *errdetails.BadRequest
 	}
+
+	// DebugDetailsGenerator interface for generating gRPC request info and debug info details
+	DebugDetailsGenerator interface {
+		NewRequestInfo(requestID, servingData string) *errdetails.RequestInfo
+		NewDebugInfo(stackEntries []string, detail string) *errdetails.DebugInfo
+	}
+
+	// ErrorInfoGenerator interface for generating gRPC error info details
+	ErrorInfoGenerator interface {
+		NewErrorInfo(reason, domain string, metadata map[string]string) *errdetails.ErrorInfo
+	}
+
+	// QuotaFailureGenerator interface for generating gRPC quota failure details
+	QuotaFailureGenerator interface {
+		NewQuotaViolation(subject, description string) *errdetails.QuotaFailure_Violation
+		NewQuotaFailure(violations []*errdetails.QuotaFailure_Violation) *errdetails.QuotaFailure
+		NewSingleQuotaFailure(subject, description string) *errdetails.QuotaFailure
+	}
+
+	// RetryInfoGenerator interface for generating gRPC retry info details
+	RetryInfoGenerator interface {
+		NewRetryInfo(retryDelay time.Duration) *errdetails.RetryInfo
+	}
 )
@@ -0,0 +1,193 @@
+package gogrpc
+
+import (
+	"slices"
+	"strings"
+)
+
+type (
+	// methodRule is a prefix rule of a method matcher
+	methodRule[T any] struct {
+		prefix   string
+		excluded bool
+		value    T
+	}
+
+	// MethodMatcher matches gRPC full method names against a set of patterns
+	//
+	// The supported patterns are:
+	//
+	//   - exact full method names, e.g. "/pkg.Service/Method"
+	//   - service wildcards, e.g. "/pkg.Service/*"
+	//   - package wildcards, e.g. "/pkg.*"
+	//   - exclusions of any of the above, prefixed with "!", e.g. "!/pkg.Service/Health"
+	//
+	// When several patterns match a method the most specific one wins: exact names win over wildcards and longer
+	// wildcards win over shorter ones. On a tie, the exclusion wins
+	MethodMatcher[T any] struct {
+		exact         map[string]T
+		exactExcluded map[string]struct{}
+		rules         []methodRule[T]
+	}
+)
+
+// parseMethodPattern parses a method pattern
+//
+// Parameters:
+//
+//   - pattern: the method pattern to parse
+//
+// Returns:
+//
+//   - string: the full method name or the wildcard prefix
+//   - bool: true if the pattern is a wildcard
+//   - bool: true if the pattern is an exclusion
+//   - error: if the pattern is invalid
+func parseMethodPattern(pattern string) (string, bool, bool, error) {
+	original := pattern
+
+	// Check if the pattern is an exclusion
+	excluded := strings.HasPrefix(pattern, ExclusionMethodPatternPrefix)
+	if excluded {
+		pattern = strings.TrimPrefix(pattern, ExclusionMethodPatternPrefix)
+	}
+
+	// Check if the pattern starts with a slash
+	if !strings.HasPrefix(pattern, "/") {
+		return "", false, false, NewInvalidMethodPatternError(original)
+	}
+
+	// Check if the pattern is a wildcard
+	wildcard := strings.HasSuffix(pattern, WildcardMethodPattern)
+	if wildcard {
+		pattern = strings.TrimSuffix(pattern, WildcardMethodPattern)
+		if !strings.HasSuffix(pattern, "/") && !strings.HasSuffix(pattern, ".") {
+			return "", false, false, NewInvalidMethodPatternError(original)
+		}
+	}
+
+	// Check if the pattern contains other wildcards
+	if strings.Contains(pattern, WildcardMethodPattern) {
+		return "", false, false, NewInvalidMethodPatternError(original)
+	}
+	return pattern, wildcard, excluded, nil
+}
+
+// NewMethodMatcher creates a new method matcher
+//
+// Parameters:
+//
+//   - patterns: the method patterns and their values, the values of the exclusions are ignored
+//
+// Returns:
+//
+//   - *MethodMatcher[T]: the method matcher
+//   - error: if the patterns map is nil or any pattern is invalid
+func NewMethodMatcher[T any](patterns map[string]T) (*MethodMatcher[T], error) {
+	// Check if the patterns map is nil
+	if patterns == nil {
+		return nil, ErrNilInterceptions
+	}
+
+	m := &MethodMatcher[T]{
+		exact:         make(map[string]T),
+		exactExcluded: make(map[string]struct{}),
+	}
+	for pattern, value := range patterns {
+		parsedPattern, wildcard, excluded, err := parseMethodPattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case wildcard:
+			m.rules = append(
+				m.rules, methodRule[T]{
+					prefix:   parsedPattern,
+					excluded: excluded,
+					value:    value,
+				},
+			)
+		case excluded:
+			m.exactExcluded[parsedPattern] = struct{}{}
+		default:
+			m.exact[parsedPattern] = value
+		}
+	}
+
+	// Sort the rules from the most specific to the least specific, with the exclusions first on a tie
+	slices.SortFunc(
+		m.rules, func(a, b methodRule[T]) int {
+			if len(a.prefix) != len(b.prefix) {
+				return len(b.prefix) - len(a.prefix)
+			}
+			if a.excluded != b.excluded {
+				if a.excluded {
+					return -1
+				}
+				return 1
+			}
+			return strings.Compare(a.prefix, b.prefix)
+		},
+	)
+	return m, nil
+}
+
+// NewMethodSetMatcher creates a new method matcher from a slice of method patterns
+//
+// Parameters:
+//
+//   - patterns: the method patterns
+//
+// Returns:
+//
+//   - *MethodMatcher[struct{}]: the method matcher
+//   - error: if the patterns slice is nil or any pattern is invalid
+func NewMethodSetMatcher(patterns []string) (*MethodMatcher[struct{}], error) {
+	// Check if the patterns slice is nil
+	if patterns == nil {
+		return nil, ErrNilInterceptions
+	}
+
+	set := make(map[string]struct{}, len(patterns))
+	for _, pattern := range patterns {
+		set[pattern] = struct{}{}
+	}
+	return NewMethodMatcher(set)
+}
+
+// Match returns the value of the most specific pattern that matches the given full method name
+//
+// Parameters:
+//
+//   - fullMethod: the full method name to match
+//
+// Returns:
+//
+//   - T: the value of the matched pattern
+//   - bool: true if the method matched a pattern and was not excluded, false otherwise
+func (m *MethodMatcher[T]) Match(fullMethod string) (T, bool) {
+	var zero T
+	if m == nil {
+		return zero, false
+	}
+
+	// Check the exact patterns
+	if _, ok := m.exactExcluded[fullMethod]; ok {
+		return zero, false
+	}
+	if value, ok := m.exact[fullMethod]; ok {
+		return value, true
+	}
+
+	// Check the wildcard patterns
+	for _, rule := range m.rules {
+		if strings.HasPrefix(fullMethod, rule.prefix) {
+			if rule.excluded {
+				return zero, false
+			}
+			return rule.value, true
+		}
+	}
+	return zero, false
+}
@@ -0,0 +1,151 @@
+package gogrpc
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMethodMatcherMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		patterns  map[string]string
+		method    string
+		wantValue string
+		wantMatch bool
+	}{
+		{
+			name:      "exact",
+			patterns:  map[string]string{"/pkg.Service/Method": "exact"},
+			method:    "/pkg.Service/Method",
+			wantValue: "exact",
+			wantMatch: true,
+		},
+		{
+			name:     "no match",
+			patterns: map[string]string{"/pkg.Service/Method": "exact"},
+			method:   "/pkg.Service/Other",
+		},
+		{
+			name: "exact beats wildcard",
+			patterns: map[string]string{
+				"/pkg.Service/*":      "service",
+				"/pkg.Service/Method": "exact",
+			},
+			method:    "/pkg.Service/Method",
+			wantValue: "exact",
+			wantMatch: true,
+		},
+		{
+			name: "exact inclusion beats wildcard exclusion",
+			patterns: map[string]string{
+				"!/pkg.Service/*":     "",
+				"/pkg.Service/Method": "exact",
+			},
+			method:    "/pkg.Service/Method",
+			wantValue: "exact",
+			wantMatch: true,
+		},
+		{
+			name: "longer prefix wins",
+			patterns: map[string]string{
+				"/pkg.*":         "package",
+				"/pkg.Service/*": "service",
+			},
+			method:    "/pkg.Service/Method",
+			wantValue: "service",
+			wantMatch: true,
+		},
+		{
+			name: "package wildcard matches other services",
+			patterns: map[string]string{
+				"/pkg.*":         "package",
+				"/pkg.Service/*": "service",
+			},
+			method:    "/pkg.Other/Method",
+			wantValue: "package",
+			wantMatch: true,
+		},
+		{
+			name:     "service wildcard does not match other services",
+			patterns: map[string]string{"/pkg.Service/*": "service"},
+			method:   "/pkg.ServiceV2/Method",
+		},
+		{
+			name: "longer exclusion wins",
+			patterns: map[string]string{
+				"/pkg.*":          "package",
+				"!/pkg.Service/*": "",
+			},
+			method: "/pkg.Service/Method",
+		},
+		{
+			name: "exclusion wins wildcard tie",
+			patterns: map[string]string{
+				"/pkg.Service/*":  "service",
+				"!/pkg.Service/*": "",
+			},
+			method: "/pkg.Service/Method",
+		},
+		{
+			name: "exclusion wins exact tie",
+			patterns: map[string]string{
+				"/pkg.Service/Method":  "exact",
+				"!/pkg.Service/Method": "",
+			},
+			method: "/pkg.Service/Method",
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				matcher, err := NewMethodMatcher(test.patterns)
+				if err != nil {
+					t.Fatalf("NewMethodMatcher: %v", err)
+				}
+				value, matched := matcher.Match(test.method)
+				if matched != test.wantMatch || value != test.wantValue {
+					t.Fatalf(
+						"expected (%q, %v), got (%q, %v)",
+						test.wantValue,
+						test.wantMatch,
+						value,
+						matched,
+					)
+				}
+			},
+		)
+	}
+}
+
+func TestNewMethodMatcherInvalidPatterns(t *testing.T) {
+	for _, pattern := range []string{
+		"",
+		"pkg.Service/Method",
+		"!pkg.Service/Method",
+		"/pkg.Service*",
+		"/pkg.*.Service/*",
+		"/pkg.Service/Method*",
+	} {
+		t.Run(
+			pattern, func(t *testing.T) {
+				if _, err := NewMethodSetMatcher([]string{pattern}); !errors.Is(err, ErrInvalidMethodPattern) {
+					t.Fatalf("expected ErrInvalidMethodPattern, got %v", err)
+				}
+			},
+		)
+	}
+}
+
+func TestNilMethodMatcher(t *testing.T) {
+	if _, err := NewMethodMatcher[string](nil); !errors.Is(err, ErrNilInterceptions) {
+		t.Fatalf("expected ErrNilInterceptions, got %v", err)
+	}
+	if _, err := NewMethodSetMatcher(nil); !errors.Is(err, ErrNilInterceptions) {
+		t.Fatalf("expected ErrNilInterceptions, got %v", err)
+	}
+
+	var matcher *MethodMatcher[string]
+	if value, matched := matcher.Match("/pkg.Service/Method"); matched || value != "" {
+		t.Fatalf("expected a nil matcher not to match, got (%q, %v)", value, matched)
+	}
+}
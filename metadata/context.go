@@ -63,6 +63,30 @@ func GetIncomingCtxMetadataValue(ctx context.Context, key string) ([]string, err
 	return GetMetadataValue(md, key)
 }
 
+// GetIncomingCtxMetadataToken gets a token from the incoming context metadata
+//
+// Parameters:
+//
+//   - ctx: The incoming context to get the metadata from
+//   - key: The key to get the token for
+//   - scheme: The scheme that prefixes the token, e.g. "Bearer", or empty if the token has no prefix
+//
+// Returns:
+//
+//   - string: The token
+//   - error: An error if the token is not found or any other error occurs
+func GetIncomingCtxMetadataToken(ctx context.Context, key, scheme string) (
+	string,
+	error,
+) {
+	// Get the metadata from the context
+	md, err := GetIncomingCtxMetadata(ctx)
+	if err != nil {
+		return "", err
+	}
+	return GetMetadataToken(md, key, scheme)
+}
+
 // GetIncomingCtxMetadataBearerToken gets the bearer token from the incoming context metadata
 //
 // Parameters:
@@ -146,6 +170,27 @@ func GetIncomingCtxMetadataRefreshToken(ctx context.Context) (string, error) {
 	return GetMetadataRefreshToken(md)
 }
 
+// GetOutgoingCtxMetadataToken gets a token from the outgoing context metadata
+//
+// Parameters:
+//
+//   - ctx: The outgoing context to get the token from
+//   - key: The key to get the token for
+//   - scheme: The scheme that prefixes the token, e.g. "Bearer", or empty if the token has no prefix
+//
+// Returns:
+//
+//   - string: The token
+//   - error: An error if the token is not found or any other error occurs
+func GetOutgoingCtxMetadataToken(ctx context.Context, key, scheme string) (
+	string,
+	error,
+) {
+	// Get the metadata from the context
+	md := GetOutgoingCtxMetadata(ctx)
+	return GetMetadataToken(md, key, scheme)
+}
+
 // GetOutgoingCtxMetadataAuthorizationToken gets the authorization token from the outgoing context metadata
 //
 // Parameters:
@@ -216,6 +261,27 @@ func GetOutgoingCtxMetadataAccessToken(ctx context.Context) (string, error) {
 	return GetMetadataAccessToken(md)
 }
 
+// SetOutgoingCtxMetadataToken sets a token to the outgoing context metadata
+//
+// Parameters:
+//
+//   - ctx: The outgoing context to set the token to
+//   - key: The metadata key where the token will be set
+//   - scheme: The scheme that prefixes the token, e.g. "Bearer", or empty if the token has no prefix
+//   - token: The token to set
+//
+// Returns:
+//
+//   - context.Context: The context with the token set
+//   - error: An error if the metadata is not found or any other error occurs
+func SetOutgoingCtxMetadataToken(
+	ctx context.Context,
+	key, scheme, token string,
+) (context.Context, error) {
+	md := SetMetadataToken(GetOutgoingCtxMetadata(ctx), key, scheme, token)
+	return metadata.NewOutgoingContext(ctx, md), nil
+}
+
 // SetOutgoingCtxMetadataBearerToken sets the authorization token to the metadata
 //
 // Parameters:
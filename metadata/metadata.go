@@ -44,20 +44,21 @@ func DeleteMetadataValue(md metadata.MD, key string) metadata.MD {
 	return md
 }
 
-// GetMetadataBearerToken gets the bearer token from the metadata
+// GetMetadataToken gets a token from the metadata
 //
 // Parameters:
 //
 //   - md: The metadata to get the token from
 //   - key: The key to get the token for
+//   - scheme: The scheme that prefixes the token, e.g. "Bearer", or empty if the token has no prefix
 //
 // Returns:
 //
 //   - string: The token
 //   - error: An error if the token is not found or any other error occurs
-func GetMetadataBearerToken(md metadata.MD, key string) (string, error) {
+func GetMetadataToken(md metadata.MD, key, scheme string) (string, error) {
 	// Get the value from the metadata
-	value, err := GetMetadataValue(md, gogrpc.AuthorizationMetadataKey)
+	value, err := GetMetadataValue(md, key)
 	if err != nil {
 		return "", err
 	}
@@ -70,17 +71,41 @@ func GetMetadataBearerToken(md metadata.MD, key string) (string, error) {
 	// Get the authorization value from the metadata
 	authorizationValue := value[gogrpc.AuthorizationMetadataIndex]
 
+	// Check if the token has no scheme
+	if scheme == "" {
+		token := strings.TrimSpace(authorizationValue)
+		if token == "" {
+			return "", ErrAuthorizationMetadataNotProvided
+		}
+		return token, nil
+	}
+
 	// Split the authorization value by space
 	authorizationFields := strings.Split(authorizationValue, " ")
 
 	// Check if the authorization value is valid
-	if len(authorizationFields) != 2 || authorizationFields[0] != gojwt.BearerPrefix {
+	if len(authorizationFields) != 2 || authorizationFields[0] != scheme {
 		return "", ErrAuthorizationMetadataInvalid
 	}
 
 	return authorizationFields[1], nil
 }
 
+// GetMetadataBearerToken gets the bearer token from the metadata
+//
+// Parameters:
+//
+//   - md: The metadata to get the token from
+//   - key: The key to get the token for
+//
+// Returns:
+//
+//   - string: The token
+//   - error: An error if the token is not found or any other error occurs
+func GetMetadataBearerToken(md metadata.MD, key string) (string, error) {
+	return GetMetadataToken(md, key, gojwt.BearerPrefix)
+}
+
 // GetMetadataAuthorizationToken gets the authorization token from the metadata
 //
 // Parameters:
@@ -123,6 +148,27 @@ func GetMetadataRefreshToken(md metadata.MD) (string, error) {
 	return GetMetadataBearerToken(md, gogrpc.RefreshTokenMetadataKey)
 }
 
+// SetMetadataToken sets a token to the metadata
+//
+// Parameters:
+//
+//   - md: The metadata to set the token to
+//   - key: The metadata key where the token will be set
+//   - scheme: The scheme that prefixes the token, e.g. "Bearer", or empty if the token has no prefix
+//   - token: The token to set
+//
+// Returns:
+//
+//   - metadata.MD: The metadata with the token set
+func SetMetadataToken(md metadata.MD, key, scheme, token string) metadata.MD {
+	if scheme == "" {
+		md.Set(key, token)
+	} else {
+		md.Set(key, scheme+" "+token)
+	}
+	return md
+}
+
 // SetMetadataBearerToken sets the authorization token to the metadata
 //
 // Parameters:
@@ -135,8 +181,7 @@ func GetMetadataRefreshToken(md metadata.MD) (string, error) {
 //
 //   - metadata.MD: The metadata with the token set
 func SetMetadataBearerToken(md metadata.MD, key, token string) metadata.MD {
-	md.Set(key, gojwt.BearerPrefix+" "+token)
-	return md
+	return SetMetadataToken(md, key, gojwt.BearerPrefix, token)
 }
 
 // SetMetadataAuthorizationToken sets the authorization token to the metadata
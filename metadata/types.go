@@ -0,0 +1,78 @@
+package metadata
+
+import (
+	gojwt "github.com/ralvarezdev/go-jwt"
+
+	gogrpc "github.com/ralvarezdev/go-grpc"
+)
+
+type (
+	// TokenOptions are the options to locate a token in the metadata
+	//
+	// The zero value, like nil options, locates a bearer token in the authorization metadata
+	TokenOptions struct {
+		// Key is the metadata key of the token, if empty the authorization metadata key is used
+		Key string
+
+		// Scheme is the scheme that prefixes the token, if empty the bearer prefix is used
+		Scheme string
+
+		// NoScheme states that the token has no scheme prefix, in which case Scheme is ignored
+		NoScheme bool
+	}
+)
+
+// NewAuthorizationTokenOptions creates the token options of a bearer token in the authorization metadata
+//
+// Returns:
+//
+//   - *TokenOptions: the token options
+func NewAuthorizationTokenOptions() *TokenOptions {
+	return &TokenOptions{
+		Key:    gogrpc.AuthorizationMetadataKey,
+		Scheme: gojwt.BearerPrefix,
+	}
+}
+
+// NewAPIKeyTokenOptions creates the token options of an API key without a scheme in the API key metadata
+//
+// Returns:
+//
+//   - *TokenOptions: the token options
+func NewAPIKeyTokenOptions() *TokenOptions {
+	return &TokenOptions{
+		Key:      gogrpc.APIKeyMetadataKey,
+		NoScheme: true,
+	}
+}
+
+// GetKey returns the metadata key of the token
+//
+// Returns:
+//
+//   - string: the metadata key, or the authorization metadata key if the options are nil or the key is empty
+func (t *TokenOptions) GetKey() string {
+	if t == nil || t.Key == "" {
+		return gogrpc.AuthorizationMetadataKey
+	}
+	return t.Key
+}
+
+// GetScheme returns the scheme that prefixes the token
+//
+// Returns:
+//
+//   - string: the scheme, an empty string if the token has no scheme, or the bearer prefix if the options are nil
+//     or the scheme is empty
+func (t *TokenOptions) GetScheme() string {
+	if t == nil {
+		return gojwt.BearerPrefix
+	}
+	if t.NoScheme {
+		return ""
+	}
+	if t.Scheme == "" {
+		return gojwt.BearerPrefix
+	}
+	return t.Scheme
+}
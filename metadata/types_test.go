@@ -0,0 +1,71 @@
+package metadata
+
+import (
+	"testing"
+
+	gojwt "github.com/ralvarezdev/go-jwt"
+	"google.golang.org/grpc/metadata"
+
+	gogrpc "github.com/ralvarezdev/go-grpc"
+)
+
+func TestTokenOptions(t *testing.T) {
+	tests := []struct {
+		name       string
+		options    *TokenOptions
+		wantKey    string
+		wantScheme string
+	}{
+		{
+			name:       "nil options",
+			wantKey:    gogrpc.AuthorizationMetadataKey,
+			wantScheme: gojwt.BearerPrefix,
+		},
+		{
+			name:       "zero value",
+			options:    &TokenOptions{},
+			wantKey:    gogrpc.AuthorizationMetadataKey,
+			wantScheme: gojwt.BearerPrefix,
+		},
+		{
+			name:       "custom scheme",
+			options:    &TokenOptions{Key: "x-token", Scheme: "Token"},
+			wantKey:    "x-token",
+			wantScheme: "Token",
+		},
+		{
+			name:    "no scheme",
+			options: &TokenOptions{Scheme: "Token", NoScheme: true},
+			wantKey: gogrpc.AuthorizationMetadataKey,
+		},
+		{
+			name:    "API key",
+			options: NewAPIKeyTokenOptions(),
+			wantKey: gogrpc.APIKeyMetadataKey,
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				if key := test.options.GetKey(); key != test.wantKey {
+					t.Fatalf("expected key %q, got %q", test.wantKey, key)
+				}
+				if scheme := test.options.GetScheme(); scheme != test.wantScheme {
+					t.Fatalf("expected scheme %q, got %q", test.wantScheme, scheme)
+				}
+
+				// The token must round trip through the metadata
+				md := SetMetadataToken(
+					metadata.MD{},
+					test.options.GetKey(),
+					test.options.GetScheme(),
+					"token",
+				)
+				token, err := GetMetadataToken(md, test.options.GetKey(), test.options.GetScheme())
+				if err != nil || token != "token" {
+					t.Fatalf("expected the token to round trip, got %q (%v)", token, err)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,31 @@
+package context
+
+var (
+	// CtxAuthSchemeKey is the key for the authentication scheme to be set to the context
+	CtxAuthSchemeKey CtxKey = "auth_scheme"
+
+	// CtxPrincipalKey is the key for the principal to be set to the context
+	CtxPrincipalKey CtxKey = "principal"
+)
+
+const (
+	// DefaultRolesClaim is the default claim used to get the roles of the principal
+	DefaultRolesClaim = "roles"
+
+	// DefaultTenantClaim is the default claim used to get the tenant of the principal
+	DefaultTenantClaim = "tenant_id"
+)
+
+var (
+	// AuthSchemeAPIKey is the API key authentication scheme
+	AuthSchemeAPIKey AuthScheme = "api_key"
+
+	// AuthSchemeJWT is the JWT authentication scheme
+	AuthSchemeJWT AuthScheme = "jwt"
+
+	// AuthSchemeMTLS is the mutual TLS client certificate authentication scheme
+	AuthSchemeMTLS AuthScheme = "mtls"
+
+	// AuthSchemeGCloud is the Google Cloud ID token authentication scheme
+	AuthSchemeGCloud AuthScheme = "gcloud"
+)
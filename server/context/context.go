@@ -36,3 +36,42 @@ func GetClientIP(ctx context.Context) (string, error) {
 
 	return ip, nil
 }
+
+// SetCtxAuthScheme sets the authentication scheme used by the caller to the context
+//
+// Parameters:
+//
+//   - ctx: The context to set the authentication scheme to
+//   - scheme: The authentication scheme to set
+//
+// Returns:
+//
+//   - context.Context: The context with the authentication scheme set
+func SetCtxAuthScheme(ctx context.Context, scheme AuthScheme) context.Context {
+	return context.WithValue(ctx, CtxAuthSchemeKey, scheme)
+}
+
+// GetCtxAuthScheme gets the authentication scheme used by the caller from the context
+//
+// Parameters:
+//
+//   - ctx: The context to get the authentication scheme from
+//
+// Returns:
+//
+//   - AuthScheme: The authentication scheme
+//   - error: An error if the authentication scheme is not found or is of an unexpected type
+func GetCtxAuthScheme(ctx context.Context) (AuthScheme, error) {
+	// Get the authentication scheme from the context
+	value := ctx.Value(CtxAuthSchemeKey)
+	if value == nil {
+		return "", ErrMissingAuthSchemeInContext
+	}
+
+	// Check the type of the value
+	scheme, ok := value.(AuthScheme)
+	if !ok {
+		return "", ErrUnexpectedAuthSchemeTypeInContext
+	}
+	return scheme, nil
+}
@@ -5,5 +5,9 @@ import (
 )
 
 var (
-	ErrFailedToGetPeerFromContext = errors.New("failed to get peer from context")
+	ErrFailedToGetPeerFromContext        = errors.New("failed to get peer from context")
+	ErrMissingAuthSchemeInContext        = errors.New("missing authentication scheme in context")
+	ErrUnexpectedAuthSchemeTypeInContext = errors.New("unexpected authentication scheme type in context")
+	ErrMissingPrincipalInContext         = errors.New("missing principal in context")
+	ErrUnexpectedPrincipalTypeInContext  = errors.New("unexpected principal type in context")
 )
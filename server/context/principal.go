@@ -0,0 +1,159 @@
+package context
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GetClaimValues gets the values of a claim that can be either a space-separated string or a list
+//
+// Parameters:
+//
+//   - claims: The token claims
+//   - claim: The claim to get the values from
+//
+// Returns:
+//
+//   - []string: The values of the claim
+func GetClaimValues(claims map[string]any, claim string) []string {
+	switch value := claims[claim].(type) {
+	case string:
+		return strings.Fields(value)
+	case []string:
+		return value
+	case []any:
+		values := make([]string, 0, len(value))
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// FormatClaimValue formats a claim value as a string, formatting the JSON numbers without the exponent notation, so
+// a decoded numeric claim like 1234567 is formatted as "1234567" instead of "1.234567e+06"
+//
+// Parameters:
+//
+//   - value: The claim value
+//
+// Returns:
+//
+//   - string: The formatted claim value
+func FormatClaimValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case json.Number:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// GetRolesClaim returns the claim used to get the roles of the principal
+//
+// Returns:
+//
+//   - string: The roles claim, or DefaultRolesClaim if the options are nil or the claim is empty
+func (p *PrincipalOptions) GetRolesClaim() string {
+	if p == nil || p.RolesClaim == "" {
+		return DefaultRolesClaim
+	}
+	return p.RolesClaim
+}
+
+// GetTenantClaim returns the claim used to get the tenant of the principal
+//
+// Returns:
+//
+//   - string: The tenant claim, or DefaultTenantClaim if the options are nil or the claim is empty
+func (p *PrincipalOptions) GetTenantClaim() string {
+	if p == nil || p.TenantClaim == "" {
+		return DefaultTenantClaim
+	}
+	return p.TenantClaim
+}
+
+// NewClaimsPrincipal creates a new principal from the token claims
+//
+// Parameters:
+//
+//   - scheme: The authentication scheme used by the caller
+//   - subject: The identifier of the caller
+//   - claims: The token claims, used to get the roles and the tenant and set as the attributes
+//   - options: The claims used to get the roles and the tenant (optional, can be nil)
+//
+// Returns:
+//
+//   - *Principal: The principal
+func NewClaimsPrincipal(
+	scheme AuthScheme,
+	subject string,
+	claims map[string]any,
+	options *PrincipalOptions,
+) *Principal {
+	principal := &Principal{
+		Subject:    subject,
+		AuthScheme: scheme,
+		Roles:      GetClaimValues(claims, options.GetRolesClaim()),
+		Attributes: claims,
+	}
+	if tenant, ok := claims[options.GetTenantClaim()]; ok && tenant != nil {
+		principal.Tenant = FormatClaimValue(tenant)
+	}
+	return principal
+}
+
+// SetCtxPrincipal sets the principal and its authentication scheme to the context
+//
+// Parameters:
+//
+//   - ctx: The context to set the principal to
+//   - principal: The principal to set
+//
+// Returns:
+//
+//   - context.Context: The context with the principal set
+func SetCtxPrincipal(ctx context.Context, principal *Principal) context.Context {
+	if principal != nil {
+		ctx = SetCtxAuthScheme(ctx, principal.AuthScheme)
+	}
+	return context.WithValue(ctx, CtxPrincipalKey, principal)
+}
+
+// GetPrincipal gets the principal from the context, independently of the authentication scheme used by the caller
+//
+// Parameters:
+//
+//   - ctx: The context to get the principal from
+//
+// Returns:
+//
+//   - *Principal: The principal
+//   - error: An error if the principal is not found or is of an unexpected type
+func GetPrincipal(ctx context.Context) (*Principal, error) {
+	// Get the principal from the context
+	value := ctx.Value(CtxPrincipalKey)
+	if value == nil {
+		return nil, ErrMissingPrincipalInContext
+	}
+
+	// Check the type of the value
+	principal, ok := value.(*Principal)
+	if !ok || principal == nil {
+		return nil, ErrUnexpectedPrincipalTypeInContext
+	}
+	return principal, nil
+}
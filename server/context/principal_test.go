@@ -0,0 +1,97 @@
+package context
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestNewClaimsPrincipal(t *testing.T) {
+	claims := map[string]any{
+		"roles":     []any{"admin", "user"},
+		"groups":    "editor viewer",
+		"tenant_id": "acme",
+		"org":       float64(1234567),
+	}
+
+	tests := []struct {
+		name       string
+		options    *PrincipalOptions
+		wantRoles  []string
+		wantTenant string
+	}{
+		{
+			name:       "default claims",
+			wantRoles:  []string{"admin", "user"},
+			wantTenant: "acme",
+		},
+		{
+			name: "configured claims",
+			options: &PrincipalOptions{
+				RolesClaim:  "groups",
+				TenantClaim: "org",
+			},
+			wantRoles:  []string{"editor", "viewer"},
+			wantTenant: "1234567",
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				principal := NewClaimsPrincipal(AuthSchemeJWT, "subject", claims, test.options)
+				if principal.Subject != "subject" || principal.AuthScheme != AuthSchemeJWT {
+					t.Fatalf("unexpected principal %+v", principal)
+				}
+				if !slices.Equal(principal.Roles, test.wantRoles) {
+					t.Fatalf("expected roles %v, got %v", test.wantRoles, principal.Roles)
+				}
+				if principal.Tenant != test.wantTenant {
+					t.Fatalf("expected tenant %q, got %q", test.wantTenant, principal.Tenant)
+				}
+			},
+		)
+	}
+}
+
+func TestFormatClaimValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{name: "string", value: "acme", expected: "acme"},
+		{name: "integer float", value: float64(1234567), expected: "1234567"},
+		{name: "large integer float", value: float64(12345678901), expected: "12345678901"},
+		{name: "fractional float", value: 1.5, expected: "1.5"},
+		{name: "JSON number", value: json.Number("1234567"), expected: "1234567"},
+		{name: "boolean", value: true, expected: "true"},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				if formatted := FormatClaimValue(test.value); formatted != test.expected {
+					t.Fatalf("expected %q, got %q", test.expected, formatted)
+				}
+			},
+		)
+	}
+}
+
+func TestGetPrincipal(t *testing.T) {
+	if _, err := GetPrincipal(context.Background()); !errors.Is(err, ErrMissingPrincipalInContext) {
+		t.Fatalf("expected ErrMissingPrincipalInContext, got %v", err)
+	}
+
+	principal := &Principal{Subject: "subject", AuthScheme: AuthSchemeAPIKey}
+	ctx := SetCtxPrincipal(context.Background(), principal)
+	got, err := GetPrincipal(ctx)
+	if err != nil || got != principal {
+		t.Fatalf("expected the principal, got %v (%v)", got, err)
+	}
+	scheme, err := GetCtxAuthScheme(ctx)
+	if err != nil || scheme != AuthSchemeAPIKey {
+		t.Fatalf("expected the API key scheme, got %q (%v)", scheme, err)
+	}
+}
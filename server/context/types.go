@@ -0,0 +1,45 @@
+package context
+
+type (
+	// CtxKey is the type for the context keys
+	CtxKey string
+
+	// AuthScheme is the authentication scheme used by the caller
+	AuthScheme string
+
+	// Principal is the authenticated caller, independent of the authentication scheme
+	Principal struct {
+		// Subject is the identifier of the caller
+		Subject string
+
+		// AuthScheme is the authentication scheme used by the caller
+		AuthScheme AuthScheme
+
+		// Roles are the roles of the caller
+		Roles []string
+
+		// Tenant is the tenant of the caller
+		Tenant string
+
+		// Attributes are the raw attributes of the authentication scheme, e.g. the token claims
+		Attributes map[string]any
+	}
+
+	// PrincipalOptions are the options to build a principal from the token claims
+	PrincipalOptions struct {
+		// RolesClaim is the claim used to get the roles of the principal, if empty DefaultRolesClaim is used
+		RolesClaim string
+
+		// TenantClaim is the claim used to get the tenant of the principal, if empty DefaultTenantClaim is used
+		TenantClaim string
+	}
+)
+
+// String returns the string representation of the authentication scheme
+//
+// Returns:
+//
+//   - string: The string representation of the authentication scheme
+func (a AuthScheme) String() string {
+	return string(a)
+}
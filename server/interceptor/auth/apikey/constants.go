@@ -0,0 +1,10 @@
+package apikeys
+
+import (
+	gogrpcservercontext "github.com/ralvarezdev/go-grpc/server/context"
+)
+
+var (
+	// CtxAPIKeyKey is the key for the scoped API key information to be set to the context
+	CtxAPIKeyKey gogrpcservercontext.CtxKey = "api_key"
+)
@@ -0,0 +1,44 @@
+package apikeys
+
+import (
+	"context"
+)
+
+// SetCtxAPIKey sets the scoped API key information to the context
+//
+// Parameters:
+//
+//   - ctx: The context to set the API key information to
+//   - apiKey: The API key information to set
+//
+// Returns:
+//
+//   - context.Context: The context with the API key information set
+func SetCtxAPIKey(ctx context.Context, apiKey *APIKey) context.Context {
+	return context.WithValue(ctx, CtxAPIKeyKey, apiKey)
+}
+
+// GetCtxAPIKey gets the scoped API key information from the context
+//
+// Parameters:
+//
+//   - ctx: The context to get the API key information from
+//
+// Returns:
+//
+//   - *APIKey: The API key information
+//   - error: An error if the API key information is not found or is of an unexpected type
+func GetCtxAPIKey(ctx context.Context) (*APIKey, error) {
+	// Get the API key information from the context
+	value := ctx.Value(CtxAPIKeyKey)
+	if value == nil {
+		return nil, ErrMissingAPIKeyInContext
+	}
+
+	// Check the type of the value
+	apiKey, ok := value.(*APIKey)
+	if !ok {
+		return nil, ErrUnexpectedAPIKeyTypeInContext
+	}
+	return apiKey, nil
+}
@@ -5,5 +5,11 @@ import (
 )
 
 var (
-	ErrNoAPIKeysProvided = errors.New("no API keys provided")
+	ErrNoAPIKeysProvided             = errors.New("no API keys provided")
+	ErrInvalidAPIKey                 = errors.New("invalid API key")
+	ErrAPIKeyNotFound                = errors.New("API key not found")
+	ErrMethodNotAllowed              = errors.New("method not allowed for the API key")
+	ErrEmptyAPIKey                   = errors.New("API key cannot be empty")
+	ErrMissingAPIKeyInContext        = errors.New("missing API key in context")
+	ErrUnexpectedAPIKeyTypeInContext = errors.New("unexpected API key type in context")
 )
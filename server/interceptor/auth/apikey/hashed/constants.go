@@ -0,0 +1,28 @@
+package hashed
+
+import (
+	"time"
+)
+
+const (
+	// HashAlgorithm is the algorithm of the API key hashes
+	HashAlgorithm = "sha256"
+
+	// HashSeparator is the separator of the algorithm, salt and digest of the API key hashes
+	HashSeparator = "$"
+
+	// SaltLength is the length in bytes of the API key hash salts
+	SaltLength = 16
+
+	// DefaultAPIKeyLength is the default length in bytes of the generated API keys
+	DefaultAPIKeyLength = 32
+
+	// DefaultWatchInterval is the default interval to check if the keys file changed
+	DefaultWatchInterval = 10 * time.Second
+
+	// StatusActive is the status of the active API keys
+	StatusActive = "active"
+
+	// StatusRetiring is the status of the API keys being rotated out, which keep working until they expire
+	StatusRetiring = "retiring"
+)
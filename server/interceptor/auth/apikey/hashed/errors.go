@@ -0,0 +1,15 @@
+package hashed
+
+import (
+	"errors"
+)
+
+var (
+	ErrEmptyPath                = errors.New("keys file path cannot be empty")
+	ErrInvalidHash              = errors.New("invalid API key hash")
+	ErrInvalidStatus            = errors.New("invalid API key status")
+	ErrRetiringKeyWithoutExpiry = errors.New("retiring API key must have an expiry")
+	ErrEmptyName                = errors.New("API key name cannot be empty")
+	ErrInvalidAPIKeyLength      = errors.New("API key length must be positive")
+	ErrInvalidLimits            = errors.New("invalid API key limits")
+)
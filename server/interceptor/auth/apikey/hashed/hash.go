@@ -0,0 +1,133 @@
+package hashed
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+type (
+	// Hash is a parsed salted API key hash
+	Hash struct {
+		salt   []byte
+		digest []byte
+	}
+)
+
+// digest computes the salted digest of an API key
+//
+// Parameters:
+//
+//   - salt: the salt
+//   - apiKey: the API key
+//
+// Returns:
+//
+//   - []byte: the digest
+func digest(salt []byte, apiKey string) []byte {
+	hash := sha256.New()
+	hash.Write(salt)
+	hash.Write([]byte(apiKey))
+	return hash.Sum(nil)
+}
+
+// GenerateAPIKey generates a new random API key
+//
+// Parameters:
+//
+//   - length: the length in bytes of the API key before encoding
+//
+// Returns:
+//
+//   - string: the base64url encoded API key
+//   - error: if the length is not positive or the random bytes could not be read
+func GenerateAPIKey(length int) (string, error) {
+	if length <= 0 {
+		return "", ErrInvalidAPIKeyLength
+	}
+
+	key := make([]byte, length)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(key), nil
+}
+
+// HashAPIKey hashes an API key with a random salt
+//
+// Parameters:
+//
+//   - apiKey: the API key to hash
+//
+// Returns:
+//
+//   - string: the hash, formatted as "sha256$<salt>$<digest>" with the salt and digest hex encoded
+//   - error: if the salt could not be generated
+func HashAPIKey(apiKey string) (string, error) {
+	salt := make([]byte, SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	return strings.Join(
+		[]string{
+			HashAlgorithm,
+			hex.EncodeToString(salt),
+			hex.EncodeToString(digest(salt, apiKey)),
+		},
+		HashSeparator,
+	), nil
+}
+
+// ParseHash parses a salted API key hash
+//
+// Parameters:
+//
+//   - hash: the hash, formatted as "sha256$<salt>$<digest>"
+//
+// Returns:
+//
+//   - *Hash: the parsed hash
+//   - error: if the hash is invalid
+func ParseHash(hash string) (*Hash, error) {
+	parts := strings.Split(hash, HashSeparator)
+	if len(parts) != 3 || parts[0] != HashAlgorithm {
+		return nil, ErrInvalidHash
+	}
+	salt, err := hex.DecodeString(parts[1])
+	if err != nil || len(salt) == 0 {
+		return nil, ErrInvalidHash
+	}
+	hashDigest, err := hex.DecodeString(parts[2])
+	if err != nil || len(hashDigest) != sha256.Size {
+		return nil, ErrInvalidHash
+	}
+	return &Hash{
+		salt:   salt,
+		digest: hashDigest,
+	}, nil
+}
+
+// Matches checks in constant time if the API key matches the hash
+//
+// Parameters:
+//
+//   - apiKey: the API key to check
+//
+// Returns:
+//
+//   - bool: true if the API key matches the hash, false otherwise
+func (h Hash) Matches(apiKey string) bool {
+	return subtle.ConstantTimeCompare(digest(h.salt, apiKey), h.digest) == 1
+}
+
+// ID returns the hex encoded digest of the hash, which uniquely identifies the hashed API key
+//
+// Returns:
+//
+//   - string: the identifier of the hashed API key
+func (h Hash) ID() string {
+	return hex.EncodeToString(h.digest)
+}
@@ -0,0 +1,325 @@
+package hashed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+
+	gogrpc "github.com/ralvarezdev/go-grpc"
+	gogrpcapikey "github.com/ralvarezdev/go-grpc/server/interceptor/auth/apikey"
+)
+
+type (
+	// entry is a compiled hashed API key
+	entry struct {
+		hash      *Hash
+		apiKey    *gogrpcapikey.APIKey
+		retiring  bool
+		expiresAt time.Time
+	}
+
+	// Store is a scoped API key service that keeps salted hashes of the API keys loaded from a JSON or YAML file
+	Store struct {
+		path    string
+		mutex   sync.RWMutex
+		entries []entry
+		modTime time.Time
+		logger  *slog.Logger
+	}
+)
+
+// NewStore creates a new hashed API key store and loads the keys file
+//
+// Parameters:
+//
+//   - path: the path of the keys file, parsed as YAML if its extension is .yaml or .yml and as JSON otherwise
+//   - logger: the logger to use (optional, can be nil)
+//
+// Returns:
+//
+//   - *Store: the hashed API key store
+//   - error: if the path is empty or the keys file could not be loaded
+func NewStore(path string, logger *slog.Logger) (*Store, error) {
+	// Check if the path is empty
+	if path == "" {
+		return nil, ErrEmptyPath
+	}
+
+	if logger != nil {
+		logger = logger.With(
+			slog.String("service", "api_key_hashed"),
+		)
+	}
+
+	s := &Store{
+		path:   path,
+		logger: logger,
+	}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// ParseKeysConfig parses the content of a keys file
+//
+// Parameters:
+//
+//   - data: the content of the keys file
+//   - isYAML: whether the content is YAML or JSON
+//
+// Returns:
+//
+//   - *KeysConfig: the keys configuration
+//   - error: if the content could not be parsed
+func ParseKeysConfig(data []byte, isYAML bool) (*KeysConfig, error) {
+	var config KeysConfig
+	if isYAML {
+		if err := yaml.Unmarshal(data, &config); err != nil {
+			return nil, err
+		}
+		return &config, nil
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// compileEntry compiles a hashed API key configuration
+//
+// Parameters:
+//
+//   - config: the hashed API key configuration
+//
+// Returns:
+//
+//   - entry: the compiled hashed API key
+//   - error: if the configuration is invalid
+func compileEntry(config KeyConfig) (entry, error) {
+	// Check the name
+	if config.Name == "" {
+		return entry{}, ErrEmptyName
+	}
+
+	// Parse the hash
+	hash, err := ParseHash(config.Hash)
+	if err != nil {
+		return entry{}, fmt.Errorf("%w: %s", err, config.Name)
+	}
+
+	// Check the status
+	e := entry{hash: hash}
+	switch config.Status {
+	case "", StatusActive:
+	case StatusRetiring:
+		if config.ExpiresAt == nil {
+			return entry{}, fmt.Errorf(
+				"%w: %s",
+				ErrRetiringKeyWithoutExpiry,
+				config.Name,
+			)
+		}
+		e.retiring = true
+	default:
+		return entry{}, fmt.Errorf("%w: %s", ErrInvalidStatus, config.Name)
+	}
+	if config.ExpiresAt != nil {
+		e.expiresAt = *config.ExpiresAt
+	}
+
+	// Create the method matcher
+	e.apiKey = &gogrpcapikey.APIKey{
+		ID:    hash.ID(),
+		Name:  config.Name,
+		Owner: config.Owner,
+	}
+	if len(config.Methods) > 0 {
+		e.apiKey.Methods, err = gogrpc.NewMethodSetMatcher(config.Methods)
+		if err != nil {
+			return entry{}, fmt.Errorf("%w: %s", err, config.Name)
+		}
+	}
+
+	// Set the limits
+	if config.DailyQuota < 0 {
+		return entry{}, fmt.Errorf("%w: %s", ErrInvalidLimits, config.Name)
+	}
+	if config.RateLimit != nil && (config.RateLimit.RequestsPerSecond <= 0 || config.RateLimit.Burst < 0) {
+		return entry{}, fmt.Errorf("%w: %s", ErrInvalidLimits, config.Name)
+	}
+	if config.RateLimit != nil || config.DailyQuota > 0 {
+		e.apiKey.Limits = &gogrpcapikey.Limits{
+			DailyQuota: config.DailyQuota,
+		}
+		if config.RateLimit != nil {
+			e.apiKey.Limits.RequestsPerSecond = config.RateLimit.RequestsPerSecond
+			e.apiKey.Limits.Burst = config.RateLimit.Burst
+		}
+	}
+	return e, nil
+}
+
+// Reload reloads the keys file, keeping the previous keys if it is invalid
+//
+// Returns:
+//
+//   - error: if the keys file could not be read or is invalid
+func (s *Store) Reload() error {
+	// Get the modification time before reading, so a concurrent change is picked up by the next check
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return err
+	}
+
+	// Parse the keys file
+	extension := strings.ToLower(filepath.Ext(s.path))
+	config, err := ParseKeysConfig(
+		data,
+		extension == ".yaml" || extension == ".yml",
+	)
+	if err != nil {
+		return err
+	}
+
+	// Compile the entries
+	entries := make([]entry, 0, len(config.Keys))
+	for _, keyConfig := range config.Keys {
+		e, compileErr := compileEntry(keyConfig)
+		if compileErr != nil {
+			return compileErr
+		}
+		entries = append(entries, e)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.entries = entries
+	s.modTime = info.ModTime()
+
+	if s.logger != nil {
+		s.logger.Info(
+			"Loaded API keys file",
+			slog.String("file_path", s.path),
+			slog.Int("keys", len(entries)),
+		)
+	}
+	return nil
+}
+
+// Watch reloads the keys file in the background whenever it changes, until the context is done
+//
+// Parameters:
+//
+//   - ctx: the context that stops the watcher
+//   - interval: the interval to check if the keys file changed (optional, if zero DefaultWatchInterval is used)
+func (s *Store) Watch(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				info, err := os.Stat(s.path)
+				if err != nil {
+					if s.logger != nil {
+						s.logger.Error(
+							"Failed to check API keys file",
+							slog.String("file_path", s.path),
+							slog.String("error", err.Error()),
+						)
+					}
+					continue
+				}
+
+				s.mutex.RLock()
+				changed := !info.ModTime().Equal(s.modTime)
+				s.mutex.RUnlock()
+				if !changed {
+					continue
+				}
+				if err = s.Reload(); err != nil && s.logger != nil {
+					s.logger.Error(
+						"Failed to reload API keys file",
+						slog.String("file_path", s.path),
+						slog.String("error", err.Error()),
+					)
+				}
+			}
+		}
+	}()
+}
+
+// GetAPIKey gets the information of an API key, comparing it against every stored hash in constant time
+//
+// Parameters:
+//
+//   - apiKey: the raw API key
+//
+// Returns:
+//
+//   - *gogrpcapikey.APIKey: the API key information
+//   - error: if the API key was not found or has expired
+func (s *Store) GetAPIKey(apiKey string) (*gogrpcapikey.APIKey, error) {
+	now := time.Now()
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	// Compare the API key against every entry, without stopping at the first match, so the time taken does not
+	// reveal which entry matched
+	var matched *entry
+	for index := range s.entries {
+		if s.entries[index].hash.Matches(apiKey) && matched == nil {
+			matched = &s.entries[index]
+		}
+	}
+	if matched == nil {
+		return nil, gogrpcapikey.ErrAPIKeyNotFound
+	}
+
+	// Check if the API key has expired
+	if !matched.expiresAt.IsZero() && !matched.expiresAt.After(now) {
+		return nil, gogrpcapikey.ErrAPIKeyNotFound
+	}
+	if matched.retiring && s.logger != nil {
+		s.logger.Warn(
+			"Retiring API key used",
+			slog.String("name", matched.apiKey.Name),
+			slog.Time("expires_at", matched.expiresAt),
+		)
+	}
+	return matched.apiKey, nil
+}
+
+// IsAPIKeyValid checks if the API key is valid
+//
+// Parameters:
+//
+//   - apiKey: the raw API key
+//
+// Returns:
+//
+//   - bool: true if the API key is valid, false otherwise
+func (s *Store) IsAPIKeyValid(apiKey string) bool {
+	_, err := s.GetAPIKey(apiKey)
+	return err == nil
+}
@@ -0,0 +1,288 @@
+package hashed
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogrpcapikey "github.com/ralvarezdev/go-grpc/server/interceptor/auth/apikey"
+)
+
+// hashAPIKey hashes the given API key
+func hashAPIKey(t *testing.T, apiKey string) string {
+	t.Helper()
+
+	hash, err := HashAPIKey(apiKey)
+	if err != nil {
+		t.Fatalf("HashAPIKey: %v", err)
+	}
+	return hash
+}
+
+// writeKeysFile writes the keys file with the given configuration and returns its path
+func writeKeysFile(t *testing.T, path string, config KeysConfig) string {
+	t.Helper()
+
+	if path == "" {
+		path = filepath.Join(t.TempDir(), "keys.json")
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if err = os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return path
+}
+
+func TestHashAPIKey(t *testing.T) {
+	apiKey, err := GenerateAPIKey(DefaultAPIKeyLength)
+	if err != nil {
+		t.Fatalf("GenerateAPIKey: %v", err)
+	}
+
+	// The same API key is hashed with different salts
+	first := hashAPIKey(t, apiKey)
+	if second := hashAPIKey(t, apiKey); first == second {
+		t.Fatal("expected the hashes to use different salts")
+	}
+
+	hash, err := ParseHash(first)
+	if err != nil {
+		t.Fatalf("ParseHash: %v", err)
+	}
+	if !hash.Matches(apiKey) {
+		t.Fatal("expected the API key to match its hash")
+	}
+	if hash.Matches(apiKey + "x") {
+		t.Fatal("expected a different API key not to match")
+	}
+}
+
+func TestParseHashInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		hash string
+	}{
+		{name: "empty", hash: ""},
+		{name: "unknown algorithm", hash: "md5$00$00"},
+		{name: "missing digest", hash: "sha256$00"},
+		{name: "invalid salt", hash: "sha256$zz$00"},
+		{name: "short digest", hash: "sha256$00$00"},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				if _, err := ParseHash(test.hash); !errors.Is(err, ErrInvalidHash) {
+					t.Fatalf("expected ErrInvalidHash, got %v", err)
+				}
+			},
+		)
+	}
+}
+
+func TestGenerateAPIKeyInvalidLength(t *testing.T) {
+	if _, err := GenerateAPIKey(0); !errors.Is(err, ErrInvalidAPIKeyLength) {
+		t.Fatalf("expected ErrInvalidAPIKeyLength, got %v", err)
+	}
+}
+
+func TestStoreGetAPIKey(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	path := writeKeysFile(
+		t, "", KeysConfig{
+			Keys: []KeyConfig{
+				{
+					Name:       "active",
+					Owner:      "owner",
+					Hash:       hashAPIKey(t, "active-key"),
+					Methods:    []string{"/pkg.Service/Method"},
+					DailyQuota: 10,
+				},
+				{
+					Name:      "retiring",
+					Hash:      hashAPIKey(t, "retiring-key"),
+					Status:    StatusRetiring,
+					ExpiresAt: &future,
+				},
+				{
+					Name:      "expired",
+					Hash:      hashAPIKey(t, "expired-key"),
+					Status:    StatusRetiring,
+					ExpiresAt: &past,
+				},
+			},
+		},
+	)
+	store, err := NewStore(path, nil)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		apiKey   string
+		expected string
+	}{
+		{name: "active API key", apiKey: "active-key", expected: "active"},
+		{name: "retiring API key", apiKey: "retiring-key", expected: "retiring"},
+		{name: "expired API key", apiKey: "expired-key"},
+		{name: "unknown API key", apiKey: "unknown-key"},
+		{name: "empty API key", apiKey: ""},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				apiKey, err := store.GetAPIKey(test.apiKey)
+				if test.expected == "" {
+					if !errors.Is(err, gogrpcapikey.ErrAPIKeyNotFound) {
+						t.Fatalf("expected ErrAPIKeyNotFound, got %v", err)
+					}
+					if store.IsAPIKeyValid(test.apiKey) {
+						t.Fatal("expected the API key to be invalid")
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("GetAPIKey: %v", err)
+				}
+				if apiKey.Name != test.expected {
+					t.Fatalf("expected the %q API key, got %q", test.expected, apiKey.Name)
+				}
+			},
+		)
+	}
+
+	// The scopes and limits are compiled
+	apiKey, err := store.GetAPIKey("active-key")
+	if err != nil {
+		t.Fatalf("GetAPIKey: %v", err)
+	}
+	if !apiKey.IsMethodAllowed("/pkg.Service/Method") || apiKey.IsMethodAllowed("/pkg.Service/Other") {
+		t.Fatal("expected the API key to be scoped to its methods")
+	}
+	if apiKey.Limits == nil || apiKey.Limits.DailyQuota != 10 {
+		t.Fatalf("expected the daily quota to be set, got %+v", apiKey.Limits)
+	}
+}
+
+func TestStoreRotation(t *testing.T) {
+	path := writeKeysFile(
+		t, "", KeysConfig{
+			Keys: []KeyConfig{{Name: "old", Hash: hashAPIKey(t, "old-key")}},
+		},
+	)
+	store, err := NewStore(path, nil)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	// Rotate the old API key out, keeping it working until it expires
+	expiresAt := time.Now().Add(time.Hour)
+	writeKeysFile(
+		t, path, KeysConfig{
+			Keys: []KeyConfig{
+				{
+					Name:      "old",
+					Hash:      hashAPIKey(t, "old-key"),
+					Status:    StatusRetiring,
+					ExpiresAt: &expiresAt,
+				},
+				{Name: "new", Hash: hashAPIKey(t, "new-key")},
+			},
+		},
+	)
+	if err = store.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if !store.IsAPIKeyValid("old-key") || !store.IsAPIKeyValid("new-key") {
+		t.Fatal("expected both API keys to be valid during the rotation")
+	}
+
+	// Remove the old API key
+	writeKeysFile(
+		t, path, KeysConfig{
+			Keys: []KeyConfig{{Name: "new", Hash: hashAPIKey(t, "new-key")}},
+		},
+	)
+	if err = store.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if store.IsAPIKeyValid("old-key") || !store.IsAPIKeyValid("new-key") {
+		t.Fatal("expected only the new API key to be valid after the rotation")
+	}
+
+	// An invalid keys file keeps the previous keys
+	writeKeysFile(
+		t, path, KeysConfig{
+			Keys: []KeyConfig{{Name: "broken", Hash: "invalid"}},
+		},
+	)
+	if err = store.Reload(); !errors.Is(err, ErrInvalidHash) {
+		t.Fatalf("expected ErrInvalidHash, got %v", err)
+	}
+	if !store.IsAPIKeyValid("new-key") {
+		t.Fatal("expected the previous keys to be kept")
+	}
+}
+
+func TestNewStoreInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   KeyConfig
+		expected error
+	}{
+		{
+			name:     "empty name",
+			config:   KeyConfig{Hash: hashAPIKey(t, "key")},
+			expected: ErrEmptyName,
+		},
+		{
+			name:     "invalid status",
+			config:   KeyConfig{Name: "key", Hash: hashAPIKey(t, "key"), Status: "revoked"},
+			expected: ErrInvalidStatus,
+		},
+		{
+			name:     "retiring without expiry",
+			config:   KeyConfig{Name: "key", Hash: hashAPIKey(t, "key"), Status: StatusRetiring},
+			expected: ErrRetiringKeyWithoutExpiry,
+		},
+		{
+			name: "invalid rate limit",
+			config: KeyConfig{
+				Name:      "key",
+				Hash:      hashAPIKey(t, "key"),
+				RateLimit: &RateLimitConfig{RequestsPerSecond: 0},
+			},
+			expected: ErrInvalidLimits,
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				path := writeKeysFile(t, "", KeysConfig{Keys: []KeyConfig{test.config}})
+				if _, err := NewStore(path, nil); !errors.Is(err, test.expected) {
+					t.Fatalf("expected %v, got %v", test.expected, err)
+				}
+			},
+		)
+	}
+}
+
+func TestParseKeysConfigYAML(t *testing.T) {
+	config, err := ParseKeysConfig(
+		[]byte("keys:\n  - name: key\n    hash: sha256$00$00\n    daily_quota: 5\n"),
+		true,
+	)
+	if err != nil {
+		t.Fatalf("ParseKeysConfig: %v", err)
+	}
+	if len(config.Keys) != 1 || config.Keys[0].Name != "key" || config.Keys[0].DailyQuota != 5 {
+		t.Fatalf("unexpected keys configuration: %+v", config)
+	}
+}
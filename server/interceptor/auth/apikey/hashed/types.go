@@ -0,0 +1,49 @@
+package hashed
+
+import (
+	"time"
+)
+
+type (
+	// KeyConfig is the configuration of a hashed API key in the keys file
+	KeyConfig struct {
+		// Name is the name of the API key
+		Name string `json:"name" yaml:"name"`
+
+		// Owner is the owner of the API key
+		Owner string `json:"owner,omitempty" yaml:"owner,omitempty"`
+
+		// Hash is the salted hash of the API key, as returned by HashAPIKey
+		Hash string `json:"hash" yaml:"hash"`
+
+		// Methods are the method patterns the API key is allowed to call, if empty every method is allowed
+		Methods []string `json:"methods,omitempty" yaml:"methods,omitempty"`
+
+		// Status is the status of the API key, either "active" (the default) or "retiring"
+		Status string `json:"status,omitempty" yaml:"status,omitempty"`
+
+		// ExpiresAt is the time at which the API key stops working, required for the retiring API keys
+		ExpiresAt *time.Time `json:"expires_at,omitempty" yaml:"expires_at,omitempty"`
+
+		// RateLimit is the rate limit of the API key, if nil the API key is not rate limited
+		RateLimit *RateLimitConfig `json:"rate_limit,omitempty" yaml:"rate_limit,omitempty"`
+
+		// DailyQuota is the number of requests allowed per UTC day, if zero there is no quota
+		DailyQuota int64 `json:"daily_quota,omitempty" yaml:"daily_quota,omitempty"`
+	}
+
+	// RateLimitConfig is the token bucket rate limit configuration of a hashed API key
+	RateLimitConfig struct {
+		// RequestsPerSecond is the rate at which the token bucket is refilled
+		RequestsPerSecond float64 `json:"requests_per_second" yaml:"requests_per_second"`
+
+		// Burst is the size of the token bucket, if zero it is the requests per second rounded up
+		Burst int `json:"burst,omitempty" yaml:"burst,omitempty"`
+	}
+
+	// KeysConfig is the content of the keys file
+	KeysConfig struct {
+		// Keys are the hashed API keys
+		Keys []KeyConfig `json:"keys" yaml:"keys"`
+	}
+)
@@ -2,20 +2,26 @@ package apikeys
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 
 	goapikey "github.com/ralvarezdev/go-api-key"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	gogrpc "github.com/ralvarezdev/go-grpc"
 	gogrpcmd "github.com/ralvarezdev/go-grpc/metadata"
+	gogrpcservercontext "github.com/ralvarezdev/go-grpc/server/context"
+	gogrpcserverstream "github.com/ralvarezdev/go-grpc/server/stream"
 )
 
 type (
 	// Interceptor is the interceptor for API key authentication
 	Interceptor struct {
 		apiKeyService goapikey.BasicService
-		interceptions map[string]struct{}
+		interceptions *gogrpc.MethodMatcher[struct{}]
+		tokenOptions  *gogrpcmd.TokenOptions
 	}
 )
 
@@ -23,16 +29,20 @@ type (
 //
 // Parameters:
 //
-//   - apiKeyService: the API key basic service to validate the API keys
-//   - methodsToIntercept: a slice of method names to intercept
+//   - apiKeyService: the API key basic service to validate the API keys, if it is a ScopedService the methods each
+//     API key is allowed to call are also checked
+//   - interceptions: the method matcher to determine which methods to intercept (optional, can be nil)
+//   - tokenOptions: the metadata key and scheme of the API key (optional, if nil it is expected as a bearer token in
+//     the authorization metadata)
 //
 // Returns:
 //
 //   - *Interceptor: the interceptor
-//   - error: if no API keys are provided
+//   - error: if the API key service is nil
 func NewInterceptor(
 	apiKeyService goapikey.BasicService,
-	methodsToIntercept []string,
+	interceptions *gogrpc.MethodMatcher[struct{}],
+	tokenOptions *gogrpcmd.TokenOptions,
 ) (
 	*Interceptor,
 	error,
@@ -42,20 +52,125 @@ func NewInterceptor(
 		return nil, goapikey.ErrNilService
 	}
 
-	// Create a map of methods to intercept for efficient lookup
-	interceptions := make(map[string]struct{})
-	if len(methodsToIntercept) != 0 {
-		for _, method := range methodsToIntercept {
-			interceptions[method] = struct{}{}
-		}
-	}
-
 	return &Interceptor{
 		apiKeyService: apiKeyService,
 		interceptions: interceptions,
+		tokenOptions:  tokenOptions,
 	}, nil
 }
 
+// authenticate validates the API key of the given method
+//
+// Parameters:
+//
+//   - ctx: the context of the request
+//   - fullMethod: the full method name of the request
+//
+// Returns:
+//
+//   - context.Context: the context with the principal and, for scoped services, the API key information set, if
+//     the method is intercepted
+//   - error: a gRPC status error if the authentication failed
+func (i Interceptor) authenticate(
+	ctx context.Context,
+	fullMethod string,
+) (context.Context, error) {
+	// Check if the method should be intercepted
+	_, ok := i.interceptions.Match(fullMethod)
+	if !ok {
+		return ctx, nil
+	}
+
+	// Get the raw token from the metadata
+	rawToken, err := gogrpcmd.GetIncomingCtxMetadataToken(
+		ctx,
+		i.tokenOptions.GetKey(),
+		i.tokenOptions.GetScheme(),
+	)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	// Check the scope of the API key if the service supports it
+	if scopedService, isScoped := i.apiKeyService.(ScopedService); isScoped {
+		apiKey, getErr := scopedService.GetAPIKey(rawToken)
+		if getErr != nil || apiKey == nil {
+			return nil, status.Error(
+				codes.Unauthenticated,
+				ErrInvalidAPIKey.Error(),
+			)
+		}
+		if !apiKey.IsMethodAllowed(fullMethod) {
+			return nil, status.Error(
+				codes.PermissionDenied,
+				ErrMethodNotAllowed.Error(),
+			)
+		}
+
+		// Set the API key information and the principal to the context
+		ctx = SetCtxAPIKey(ctx, apiKey)
+		ctx = gogrpcservercontext.SetCtxPrincipal(ctx, apiKey.Principal())
+		return ctx, nil
+	}
+
+	// Validate the API key
+	if valid := i.apiKeyService.IsAPIKeyValid(rawToken); !valid {
+		return nil, status.Error(
+			codes.Unauthenticated,
+			ErrInvalidAPIKey.Error(),
+		)
+	}
+
+	// Set the principal to the context, identified by the API key hash
+	hash := sha256.Sum256([]byte(rawToken))
+	ctx = gogrpcservercontext.SetCtxPrincipal(
+		ctx,
+		&gogrpcservercontext.Principal{
+			Subject:    hex.EncodeToString(hash[:]),
+			AuthScheme: gogrpcservercontext.AuthSchemeAPIKey,
+		},
+	)
+	return ctx, nil
+}
+
+// Intercepts checks if the given method is intercepted
+//
+// Parameters:
+//
+//   - fullMethod: the full method name
+//
+// Returns:
+//
+//   - bool: true if the method is intercepted, false otherwise
+func (i Interceptor) Intercepts(fullMethod string) bool {
+	_, ok := i.interceptions.Match(fullMethod)
+	return ok
+}
+
+// AuthenticateCtx authenticates a request of the given method without passing through the methods that are not
+// intercepted
+//
+// Parameters:
+//
+//   - ctx: the context of the request
+//   - fullMethod: the full method name of the request
+//
+// Returns:
+//
+//   - context.Context: the context with the principal and, for scoped services, the API key information set
+//   - error: gogrpc.ErrMethodNotIntercepted if the method is not intercepted, or a gRPC status error if the
+//     authentication failed
+func (i Interceptor) AuthenticateCtx(
+	ctx context.Context,
+	fullMethod string,
+) (context.Context, error) {
+	// Check if the method is intercepted
+	if !i.Intercepts(fullMethod) {
+		return nil, gogrpc.ErrMethodNotIntercepted
+	}
+	return i.authenticate(ctx, fullMethod)
+}
+
 // Authenticate returns the API key authentication interceptor
 //
 // Returns:
@@ -66,22 +181,35 @@ func (i Interceptor) Authenticate() grpc.UnaryServerInterceptor {
 		ctx context.Context, req any, info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (any, error) {
-		// Check if the method should be intercepted
-		_, ok := i.interceptions[info.FullMethod]
-		if !ok {
-			return handler(ctx, req)
+		// Authenticate the request
+		ctx, err := i.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
 		}
+		return handler(ctx, req)
+	}
+}
 
-		// Get the raw token from the metadata
-		rawToken, err := gogrpcmd.GetIncomingCtxMetadataAuthorizationToken(ctx)
+// AuthenticateStream returns the API key stream authentication interceptor
+//
+// Returns:
+//
+//   - grpc.StreamServerInterceptor: the interceptor
+func (i Interceptor) AuthenticateStream() grpc.StreamServerInterceptor {
+	return func(
+		srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		// Authenticate the stream
+		ctx, err := i.authenticate(ss.Context(), info.FullMethod)
 		if err != nil {
-			return nil, status.Error(codes.Unauthenticated, err.Error())
+			return err
 		}
 
-		// Validate the API key
-		if valid := i.apiKeyService.IsAPIKeyValid(rawToken); !valid {
-			return nil, status.Error(codes.Unauthenticated, "invalid API key")
-		}
-		return handler(ctx, req)
+		// Wrap the server stream with the authenticated context
+		return handler(
+			srv,
+			gogrpcserverstream.NewWrappedServerStream(ctx, ss),
+		)
 	}
 }
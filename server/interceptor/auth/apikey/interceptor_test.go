@@ -0,0 +1,243 @@
+package apikeys
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	goapikey "github.com/ralvarezdev/go-api-key"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	gogrpc "github.com/ralvarezdev/go-grpc"
+	gogrpcservercontext "github.com/ralvarezdev/go-grpc/server/context"
+)
+
+const (
+	testMethod        = "/pkg.Service/Method"
+	testAdminMethod   = "/pkg.Service/Admin"
+	testPublicMethod  = "/pkg.Other/Public"
+	testAPIKey        = "secret"
+	testScopedAPIKey  = "scoped-secret"
+	testUnknownAPIKey = "unknown"
+)
+
+type (
+	// stubAPIKeyService accepts only the test API key
+	stubAPIKeyService struct{}
+)
+
+func (stubAPIKeyService) IsAPIKeyValid(apiKey string) bool {
+	return apiKey == testAPIKey
+}
+
+// newTestScopedService creates a scoped API key service with an API key that is only allowed to call the test method
+func newTestScopedService(t *testing.T) *MemoryScopedService {
+	t.Helper()
+
+	methods, err := gogrpc.NewMethodSetMatcher([]string{testMethod})
+	if err != nil {
+		t.Fatalf("NewMethodSetMatcher: %v", err)
+	}
+	return NewMemoryScopedService(
+		map[string]*APIKey{
+			testScopedAPIKey: {
+				ID:      "key-1",
+				Name:    "billing",
+				Owner:   "team",
+				Methods: methods,
+			},
+		},
+	)
+}
+
+// call calls the given method through the interceptor with the given API key as a bearer token and returns the
+// context reaching the handler
+func call(
+	t *testing.T,
+	interceptor *Interceptor,
+	method string,
+	apiKey string,
+) (context.Context, error) {
+	t.Helper()
+
+	ctx := context.Background()
+	if apiKey != "" {
+		ctx = metadata.NewIncomingContext(
+			ctx,
+			metadata.Pairs(gogrpc.AuthorizationMetadataKey, "Bearer "+apiKey),
+		)
+	}
+	var handlerCtx context.Context
+	_, err := interceptor.Authenticate()(
+		ctx,
+		nil,
+		&grpc.UnaryServerInfo{FullMethod: method},
+		func(innerCtx context.Context, _ any) (any, error) {
+			handlerCtx = innerCtx
+			return nil, nil
+		},
+	)
+	return handlerCtx, err
+}
+
+// newTestInterceptor creates an API key interceptor of the given service that intercepts the test service methods
+func newTestInterceptor(t *testing.T, service goapikey.BasicService) *Interceptor {
+	t.Helper()
+
+	interceptions, err := gogrpc.NewMethodSetMatcher([]string{"/pkg.Service/*"})
+	if err != nil {
+		t.Fatalf("NewMethodSetMatcher: %v", err)
+	}
+	interceptor, err := NewInterceptor(service, interceptions, nil)
+	if err != nil {
+		t.Fatalf("NewInterceptor: %v", err)
+	}
+	return interceptor
+}
+
+func TestAuthenticate(t *testing.T) {
+	tests := []struct {
+		name     string
+		service  goapikey.BasicService
+		method   string
+		apiKey   string
+		expected codes.Code
+	}{
+		{
+			name:     "public method",
+			service:  stubAPIKeyService{},
+			method:   testPublicMethod,
+			expected: codes.OK,
+		},
+		{
+			name:     "missing API key",
+			service:  stubAPIKeyService{},
+			method:   testMethod,
+			expected: codes.Unauthenticated,
+		},
+		{
+			name:     "invalid API key",
+			service:  stubAPIKeyService{},
+			method:   testMethod,
+			apiKey:   testUnknownAPIKey,
+			expected: codes.Unauthenticated,
+		},
+		{
+			name:     "valid API key",
+			service:  stubAPIKeyService{},
+			method:   testMethod,
+			apiKey:   testAPIKey,
+			expected: codes.OK,
+		},
+		{
+			name:     "unknown scoped API key",
+			service:  newTestScopedService(t),
+			method:   testMethod,
+			apiKey:   testUnknownAPIKey,
+			expected: codes.Unauthenticated,
+		},
+		{
+			name:     "scoped API key out of scope",
+			service:  newTestScopedService(t),
+			method:   testAdminMethod,
+			apiKey:   testScopedAPIKey,
+			expected: codes.PermissionDenied,
+		},
+		{
+			name:     "scoped API key in scope",
+			service:  newTestScopedService(t),
+			method:   testMethod,
+			apiKey:   testScopedAPIKey,
+			expected: codes.OK,
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				_, err := call(t, newTestInterceptor(t, test.service), test.method, test.apiKey)
+				if status.Code(err) != test.expected {
+					t.Fatalf("expected %v, got %v", test.expected, err)
+				}
+			},
+		)
+	}
+}
+
+func TestAuthenticateScopedContext(t *testing.T) {
+	handlerCtx, err := call(t, newTestInterceptor(t, newTestScopedService(t)), testMethod, testScopedAPIKey)
+	if err != nil {
+		t.Fatalf("expected the scoped API key to authenticate, got %v", err)
+	}
+
+	// Check the API key information and the principal set to the context
+	apiKey, err := GetCtxAPIKey(handlerCtx)
+	if err != nil || apiKey.Name != "billing" || apiKey.Owner != "team" {
+		t.Fatalf("expected the API key information, got %+v (%v)", apiKey, err)
+	}
+	principal, err := gogrpcservercontext.GetPrincipal(handlerCtx)
+	if err != nil || principal.Subject != "key-1" || principal.AuthScheme != gogrpcservercontext.AuthSchemeAPIKey {
+		t.Fatalf("expected the API key principal, got %+v (%v)", principal, err)
+	}
+	if principal.Attributes["name"] != "billing" || principal.Attributes["owner"] != "team" {
+		t.Fatalf("expected the name and owner attributes, got %v", principal.Attributes)
+	}
+}
+
+func TestAuthenticateCtx(t *testing.T) {
+	interceptor := newTestInterceptor(t, stubAPIKeyService{})
+	if _, err := interceptor.AuthenticateCtx(context.Background(), testPublicMethod); !errors.Is(
+		err,
+		gogrpc.ErrMethodNotIntercepted,
+	) {
+		t.Fatalf("expected ErrMethodNotIntercepted, got %v", err)
+	}
+	if interceptor.Intercepts(testPublicMethod) || !interceptor.Intercepts(testMethod) {
+		t.Fatal("expected only the test service methods to be intercepted")
+	}
+}
+
+func TestNewInterceptorNilService(t *testing.T) {
+	if _, err := NewInterceptor(nil, nil, nil); !errors.Is(err, goapikey.ErrNilService) {
+		t.Fatalf("expected ErrNilService, got %v", err)
+	}
+}
+
+func TestMemoryScopedService(t *testing.T) {
+	service := NewMemoryScopedService(
+		map[string]*APIKey{
+			"":           {Name: "empty"},
+			"nil":        nil,
+			testAPIKey:   {Name: "initial"},
+			"to-replace": {Name: "old"},
+		},
+	)
+
+	// The empty and nil API keys are skipped
+	if service.IsAPIKeyValid("") || service.IsAPIKeyValid("nil") {
+		t.Fatal("expected the empty and nil API keys to be skipped")
+	}
+	if !service.IsAPIKeyValid(testAPIKey) {
+		t.Fatal("expected the initial API key to be valid")
+	}
+
+	// Add and replace API keys
+	if err := service.Add("", &APIKey{}); !errors.Is(err, ErrEmptyAPIKey) {
+		t.Fatalf("expected ErrEmptyAPIKey, got %v", err)
+	}
+	if err := service.Add("to-replace", &APIKey{Name: "new"}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	apiKey, err := service.GetAPIKey("to-replace")
+	if err != nil || apiKey.Name != "new" {
+		t.Fatalf("expected the replaced API key, got %+v (%v)", apiKey, err)
+	}
+
+	// Remove an API key
+	service.Remove(testAPIKey)
+	if _, err = service.GetAPIKey(testAPIKey); !errors.Is(err, ErrAPIKeyNotFound) {
+		t.Fatalf("expected ErrAPIKeyNotFound, got %v", err)
+	}
+}
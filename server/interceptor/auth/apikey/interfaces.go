@@ -0,0 +1,13 @@
+package apikeys
+
+import (
+	goapikey "github.com/ralvarezdev/go-api-key"
+)
+
+type (
+	// ScopedService is the API key service that ties each API key to a name, an owner and its allowed methods
+	ScopedService interface {
+		goapikey.BasicService
+		GetAPIKey(apiKey string) (*APIKey, error)
+	}
+)
@@ -0,0 +1,6 @@
+package ratelimit
+
+const (
+	// QuotaSubjectPrefix is the prefix of the subject of the quota violations, followed by the API key name
+	QuotaSubjectPrefix = "api_key:"
+)
@@ -0,0 +1,12 @@
+package ratelimit
+
+import (
+	"errors"
+)
+
+var (
+	ErrNilStore           = errors.New("rate limit store cannot be nil")
+	ErrNilLimits          = errors.New("API key limits cannot be nil")
+	ErrRateLimitExceeded  = errors.New("API key rate limit exceeded")
+	ErrDailyQuotaExceeded = errors.New("API key daily quota exceeded")
+)
@@ -0,0 +1,161 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gogrpc "github.com/ralvarezdev/go-grpc"
+	gogrpcapikey "github.com/ralvarezdev/go-grpc/server/interceptor/auth/apikey"
+)
+
+type (
+	// Interceptor is the interceptor that enforces the rate limits and the daily quotas of the scoped API keys
+	Interceptor struct {
+		store            Store
+		detailsGenerator DetailsGenerator
+		logger           *slog.Logger
+	}
+)
+
+// NewInterceptor creates a new API key rate limit interceptor, which must be chained after the API key
+// authentication interceptor
+//
+// Parameters:
+//
+//   - store: the store of the rate limits and quotas
+//   - detailsGenerator: the error details generator (optional, can be nil)
+//   - logger: the logger to use (optional, can be nil)
+//
+// Returns:
+//
+//   - *Interceptor: the interceptor
+//   - error: if the store is nil
+func NewInterceptor(
+	store Store,
+	detailsGenerator DetailsGenerator,
+	logger *slog.Logger,
+) (*Interceptor, error) {
+	// Check if the store is nil
+	if store == nil {
+		return nil, ErrNilStore
+	}
+
+	// Set the default error details generator
+	if detailsGenerator == nil {
+		detailsGenerator = gogrpc.NewDefaultErrorDetailsGenerator(logger)
+	}
+
+	if logger != nil {
+		logger = logger.With(
+			slog.String("grpc_server_interceptor", "api_key_rate_limit"),
+		)
+	}
+
+	return &Interceptor{
+		store:            store,
+		detailsGenerator: detailsGenerator,
+		logger:           logger,
+	}, nil
+}
+
+// limit takes a request from the limits of the API key set to the context, if any
+//
+// Parameters:
+//
+//   - ctx: the context of the request
+//   - fullMethod: the full method name of the request
+//
+// Returns:
+//
+//   - error: a resource exhausted gRPC status error with the quota failure and the retry info, if the request was
+//     rejected
+func (i Interceptor) limit(ctx context.Context, fullMethod string) error {
+	// Get the API key, the requests not authenticated with a scoped API key are not limited
+	apiKey, err := gogrpcapikey.GetCtxAPIKey(ctx)
+	if err != nil || apiKey.Limits == nil {
+		return nil
+	}
+
+	// Take a request from the limits of the API key, keyed by its unique identifier so API keys sharing a name do not
+	// share their limits, and passing its current limits so the reloaded ones apply to the existing buckets
+	var retryDelay time.Duration
+	retryDelay, err = i.store.Take(ctx, apiKey.GetID(), apiKey.Limits)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, ErrRateLimitExceeded) && !errors.Is(
+		err,
+		ErrDailyQuotaExceeded,
+	) {
+		if i.logger != nil {
+			i.logger.Error(
+				"Failed to take request from API key limits",
+				slog.String("method", fullMethod),
+				slog.String("error", err.Error()),
+			)
+		}
+		return status.Error(codes.Internal, gogrpc.InternalServerError)
+	}
+
+	if i.logger != nil {
+		i.logger.Warn(
+			"API key limits exceeded",
+			slog.String("method", fullMethod),
+			slog.String("api_key_name", apiKey.Name),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	st := status.New(codes.ResourceExhausted, err.Error())
+	stWithDetails, detailsErr := st.WithDetails(
+		i.detailsGenerator.NewSingleQuotaFailure(
+			QuotaSubjectPrefix+apiKey.Name,
+			err.Error(),
+		),
+		i.detailsGenerator.NewRetryInfo(retryDelay),
+	)
+	if detailsErr != nil {
+		return st.Err()
+	}
+	return stWithDetails.Err()
+}
+
+// Limit returns the rate limit interceptor
+//
+// Returns:
+//
+//   - grpc.UnaryServerInterceptor: the interceptor
+func (i Interceptor) Limit() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req any, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if err := i.limit(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// LimitStream returns the stream rate limit interceptor, which takes a single request per stream
+//
+// Returns:
+//
+//   - grpc.StreamServerInterceptor: the interceptor
+func (i Interceptor) LimitStream() grpc.StreamServerInterceptor {
+	return func(
+		srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if err := i.limit(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,31 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+
+	gogrpc "github.com/ralvarezdev/go-grpc"
+	gogrpcapikey "github.com/ralvarezdev/go-grpc/server/interceptor/auth/apikey"
+)
+
+type (
+	// Store is the interface for the stores of the API key rate limits and quotas, which can be shared between
+	// instances
+	Store interface {
+		Take(ctx context.Context, key string, limits *gogrpcapikey.Limits) (time.Duration, error)
+	}
+
+	// DetailsGenerator interface for generating the gRPC error details of the rejected requests
+	DetailsGenerator interface {
+		gogrpc.QuotaFailureGenerator
+		gogrpc.RetryInfoGenerator
+	}
+
+	// RateLimiter interface
+	RateLimiter interface {
+		Limit() grpc.UnaryServerInterceptor
+		LimitStream() grpc.StreamServerInterceptor
+	}
+)
@@ -0,0 +1,154 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	gogrpcapikey "github.com/ralvarezdev/go-grpc/server/interceptor/auth/apikey"
+)
+
+type (
+	// memoryEntry is an entry of the in-memory store
+	memoryEntry struct {
+		tokens    float64
+		updatedAt time.Time
+		day       time.Time
+		requests  int64
+	}
+
+	// MemoryStore is an in-memory store of the API key rate limits and quotas
+	MemoryStore struct {
+		mutex   sync.Mutex
+		entries map[string]*memoryEntry
+	}
+)
+
+// NewMemoryStore creates a new in-memory store of the API key rate limits and quotas
+//
+// Returns:
+//
+//   - *MemoryStore: the in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[string]*memoryEntry),
+	}
+}
+
+// getBurst gets the size of the token bucket of the given limits
+//
+// Parameters:
+//
+//   - limits: the limits
+//
+// Returns:
+//
+//   - float64: the size of the token bucket
+func getBurst(limits *gogrpcapikey.Limits) float64 {
+	if limits.Burst > 0 {
+		return float64(limits.Burst)
+	}
+	return math.Max(1, math.Ceil(limits.RequestsPerSecond))
+}
+
+// getDay gets the start of the UTC day of the given time
+//
+// Parameters:
+//
+//   - t: the time
+//
+// Returns:
+//
+//   - time.Time: the start of the UTC day
+func getDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Take takes a request from the token bucket and the daily quota of the given key
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - key: the key
+//   - limits: the limits of the key
+//
+// Returns:
+//
+//   - time.Duration: the delay after which the request can be retried, if it was rejected
+//   - error: ErrRateLimitExceeded or ErrDailyQuotaExceeded if the request was rejected, or ErrNilLimits if the
+//     limits are nil
+func (m *MemoryStore) Take(
+	ctx context.Context,
+	key string,
+	limits *gogrpcapikey.Limits,
+) (time.Duration, error) {
+	if limits == nil {
+		return 0, ErrNilLimits
+	}
+	now := time.Now()
+	today := getDay(now)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	entry, ok := m.entries[key]
+	if !ok {
+		entry = &memoryEntry{
+			tokens:    getBurst(limits),
+			updatedAt: now,
+			day:       today,
+		}
+		m.entries[key] = entry
+	}
+
+	// Check the daily quota, starting a new day if the current one has ended
+	if !entry.day.Equal(today) {
+		entry.day = today
+		entry.requests = 0
+	}
+	if limits.DailyQuota > 0 && entry.requests >= limits.DailyQuota {
+		return today.AddDate(0, 0, 1).Sub(now), ErrDailyQuotaExceeded
+	}
+
+	// Refill the token bucket and check the rate limit
+	if limits.RequestsPerSecond > 0 {
+		elapsed := now.Sub(entry.updatedAt).Seconds()
+		entry.tokens = math.Min(
+			getBurst(limits),
+			entry.tokens+elapsed*limits.RequestsPerSecond,
+		)
+		entry.updatedAt = now
+		if entry.tokens < 1 {
+			retryDelay := (1 - entry.tokens) / limits.RequestsPerSecond
+			return time.Duration(retryDelay * float64(time.Second)), ErrRateLimitExceeded
+		}
+		entry.tokens--
+	}
+	entry.requests++
+	return 0, nil
+}
+
+// Reset resets the token bucket and the daily quota of the given key
+//
+// Parameters:
+//
+//   - key: the key
+func (m *MemoryStore) Reset(key string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.entries, key)
+}
+
+// DeleteExpired deletes the entries that have not been used since the start of the current UTC day
+func (m *MemoryStore) DeleteExpired() {
+	today := getDay(time.Now())
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for key, entry := range m.entries {
+		if entry.day.Before(today) {
+			delete(m.entries, key)
+		}
+	}
+}
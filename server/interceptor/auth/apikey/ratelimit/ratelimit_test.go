@@ -0,0 +1,175 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gogrpcapikey "github.com/ralvarezdev/go-grpc/server/interceptor/auth/apikey"
+)
+
+const (
+	testMethod = "/pkg.Service/Method"
+)
+
+// newTestInterceptor creates a rate limit interceptor backed by an in-memory store
+func newTestInterceptor(t *testing.T) *Interceptor {
+	t.Helper()
+
+	interceptor, err := NewInterceptor(NewMemoryStore(), nil, nil)
+	if err != nil {
+		t.Fatalf("NewInterceptor: %v", err)
+	}
+	return interceptor
+}
+
+// call calls the test method authenticated with the given API key
+func call(interceptor *Interceptor, apiKey *gogrpcapikey.APIKey) error {
+	ctx := context.Background()
+	if apiKey != nil {
+		ctx = gogrpcapikey.SetCtxAPIKey(ctx, apiKey)
+	}
+	_, err := interceptor.Limit()(
+		ctx,
+		nil,
+		&grpc.UnaryServerInfo{FullMethod: testMethod},
+		func(context.Context, any) (any, error) {
+			return nil, nil
+		},
+	)
+	return err
+}
+
+func TestMemoryStoreTake(t *testing.T) {
+	tests := []struct {
+		name     string
+		limits   *gogrpcapikey.Limits
+		allowed  int
+		expected error
+	}{
+		{
+			name:     "rate limit",
+			limits:   &gogrpcapikey.Limits{RequestsPerSecond: 0.001, Burst: 3},
+			allowed:  3,
+			expected: ErrRateLimitExceeded,
+		},
+		{
+			name:     "rate limit with default burst",
+			limits:   &gogrpcapikey.Limits{RequestsPerSecond: 0.001},
+			allowed:  1,
+			expected: ErrRateLimitExceeded,
+		},
+		{
+			name:     "daily quota",
+			limits:   &gogrpcapikey.Limits{DailyQuota: 2},
+			allowed:  2,
+			expected: ErrDailyQuotaExceeded,
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				store := NewMemoryStore()
+				for range test.allowed {
+					if _, err := store.Take(context.Background(), "key", test.limits); err != nil {
+						t.Fatalf("expected the request to be allowed, got %v", err)
+					}
+				}
+				retryDelay, err := store.Take(context.Background(), "key", test.limits)
+				if !errors.Is(err, test.expected) {
+					t.Fatalf("expected %v, got %v", test.expected, err)
+				}
+				if retryDelay <= 0 {
+					t.Fatalf("expected a positive retry delay, got %v", retryDelay)
+				}
+
+				// Other keys have their own limits
+				if _, err = store.Take(context.Background(), "other", test.limits); err != nil {
+					t.Fatalf("expected the other key to be allowed, got %v", err)
+				}
+			},
+		)
+	}
+}
+
+func TestMemoryStoreTakeNilLimits(t *testing.T) {
+	if _, err := NewMemoryStore().Take(context.Background(), "key", nil); !errors.Is(err, ErrNilLimits) {
+		t.Fatalf("expected ErrNilLimits, got %v", err)
+	}
+}
+
+func TestMemoryStoreTakeReloadedLimits(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	// Exhaust part of the bucket, then lower the burst and the daily quota as a hot reload would
+	limits := &gogrpcapikey.Limits{RequestsPerSecond: 0.001, Burst: 10, DailyQuota: 100}
+	for range 2 {
+		if _, err := store.Take(ctx, "key", limits); err != nil {
+			t.Fatalf("Take: %v", err)
+		}
+	}
+	reloaded := &gogrpcapikey.Limits{RequestsPerSecond: 0.001, Burst: 1, DailyQuota: 100}
+	if _, err := store.Take(ctx, "key", reloaded); err != nil {
+		t.Fatalf("Take: %v", err)
+	}
+	if _, err := store.Take(ctx, "key", reloaded); !errors.Is(err, ErrRateLimitExceeded) {
+		t.Fatalf("expected the reloaded burst to apply, got %v", err)
+	}
+
+	reloaded = &gogrpcapikey.Limits{DailyQuota: 3}
+	if _, err := store.Take(ctx, "key", reloaded); !errors.Is(err, ErrDailyQuotaExceeded) {
+		t.Fatalf("expected the reloaded daily quota to apply, got %v", err)
+	}
+}
+
+func TestLimit(t *testing.T) {
+	interceptor := newTestInterceptor(t)
+	limits := &gogrpcapikey.Limits{DailyQuota: 1}
+	first := &gogrpcapikey.APIKey{ID: "first", Name: "shared", Limits: limits}
+	second := &gogrpcapikey.APIKey{ID: "second", Name: "shared", Limits: limits}
+
+	if err := call(interceptor, first); err != nil {
+		t.Fatalf("expected the first request to be allowed, got %v", err)
+	}
+	err := call(interceptor, first)
+	st := status.Convert(err)
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+	hasQuotaFailure, hasRetryInfo := false, false
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.QuotaFailure:
+			hasQuotaFailure = len(detail.GetViolations()) == 1 &&
+				detail.GetViolations()[0].GetSubject() == QuotaSubjectPrefix+"shared"
+		case *errdetails.RetryInfo:
+			hasRetryInfo = detail.GetRetryDelay().AsDuration() > 0 &&
+				detail.GetRetryDelay().AsDuration() <= 24*time.Hour
+		}
+	}
+	if !hasQuotaFailure || !hasRetryInfo {
+		t.Fatalf("expected the quota failure and retry info details, got %v", st.Details())
+	}
+
+	// An API key with the same name but a different identifier has its own quota
+	if err = call(interceptor, second); err != nil {
+		t.Fatalf("expected the API key with the same name to have its own quota, got %v", err)
+	}
+
+	// The requests without a scoped API key or without limits are not limited
+	for range 3 {
+		if err = call(interceptor, nil); err != nil {
+			t.Fatalf("expected the request without API key to be allowed, got %v", err)
+		}
+		if err = call(interceptor, &gogrpcapikey.APIKey{Name: "unlimited"}); err != nil {
+			t.Fatalf("expected the API key without limits to be allowed, got %v", err)
+		}
+	}
+}
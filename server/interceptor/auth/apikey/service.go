@@ -0,0 +1,100 @@
+package apikeys
+
+import (
+	"sync"
+)
+
+type (
+	// MemoryScopedService is an in-memory scoped API key service
+	MemoryScopedService struct {
+		mutex   sync.RWMutex
+		apiKeys map[string]*APIKey
+	}
+)
+
+// NewMemoryScopedService creates a new in-memory scoped API key service
+//
+// Parameters:
+//
+//   - apiKeys: the API keys information keyed by the raw API key (optional, can be nil)
+//
+// Returns:
+//
+//   - *MemoryScopedService: the in-memory scoped API key service
+func NewMemoryScopedService(apiKeys map[string]*APIKey) *MemoryScopedService {
+	m := &MemoryScopedService{
+		apiKeys: make(map[string]*APIKey, len(apiKeys)),
+	}
+	for apiKey, info := range apiKeys {
+		if apiKey != "" && info != nil {
+			m.apiKeys[apiKey] = info
+		}
+	}
+	return m
+}
+
+// Add adds or replaces an API key
+//
+// Parameters:
+//
+//   - apiKey: the raw API key
+//   - info: the API key information
+//
+// Returns:
+//
+//   - error: if the API key is empty
+func (m *MemoryScopedService) Add(apiKey string, info *APIKey) error {
+	if apiKey == "" || info == nil {
+		return ErrEmptyAPIKey
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.apiKeys[apiKey] = info
+	return nil
+}
+
+// Remove removes an API key
+//
+// Parameters:
+//
+//   - apiKey: the raw API key
+func (m *MemoryScopedService) Remove(apiKey string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.apiKeys, apiKey)
+}
+
+// GetAPIKey gets the information of an API key
+//
+// Parameters:
+//
+//   - apiKey: the raw API key
+//
+// Returns:
+//
+//   - *APIKey: the API key information
+//   - error: if the API key was not found
+func (m *MemoryScopedService) GetAPIKey(apiKey string) (*APIKey, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	info, ok := m.apiKeys[apiKey]
+	if !ok {
+		return nil, ErrAPIKeyNotFound
+	}
+	return info, nil
+}
+
+// IsAPIKeyValid checks if the API key is valid
+//
+// Parameters:
+//
+//   - apiKey: the raw API key
+//
+// Returns:
+//
+//   - bool: true if the API key is valid, false otherwise
+func (m *MemoryScopedService) IsAPIKeyValid(apiKey string) bool {
+	_, err := m.GetAPIKey(apiKey)
+	return err == nil
+}
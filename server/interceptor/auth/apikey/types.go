@@ -0,0 +1,84 @@
+package apikeys
+
+import (
+	gogrpc "github.com/ralvarezdev/go-grpc"
+	gogrpcservercontext "github.com/ralvarezdev/go-grpc/server/context"
+)
+
+type (
+	// APIKey is the information of a scoped API key
+	APIKey struct {
+		// ID is the unique identifier of the API key, used to key its rate limit and quota, if empty the name is used
+		ID string
+
+		// Name is the name of the API key
+		Name string
+
+		// Owner is the owner of the API key
+		Owner string
+
+		// Methods are the methods the API key is allowed to call, if nil every method is allowed
+		Methods *gogrpc.MethodMatcher[struct{}]
+
+		// Limits are the rate limit and quota of the API key, if nil the API key is not limited
+		Limits *Limits
+	}
+
+	// Limits are the rate limit and quota of an API key
+	Limits struct {
+		// RequestsPerSecond is the rate at which the token bucket is refilled, if zero there is no rate limit
+		RequestsPerSecond float64
+
+		// Burst is the size of the token bucket, if zero it is the requests per second rounded up
+		Burst int
+
+		// DailyQuota is the number of requests allowed per UTC day, if zero there is no quota
+		DailyQuota int64
+	}
+)
+
+// IsMethodAllowed checks if the API key is allowed to call the given method
+//
+// Parameters:
+//
+//   - fullMethod: the full method name
+//
+// Returns:
+//
+//   - bool: true if the method is allowed, false otherwise
+func (a APIKey) IsMethodAllowed(fullMethod string) bool {
+	if a.Methods == nil {
+		return true
+	}
+	_, ok := a.Methods.Match(fullMethod)
+	return ok
+}
+
+// GetID returns the unique identifier of the API key, falling back to its name if it has no identifier
+//
+// Returns:
+//
+//   - string: the unique identifier of the API key
+func (a APIKey) GetID() string {
+	if a.ID != "" {
+		return a.ID
+	}
+	return a.Name
+}
+
+// Principal returns the principal of the API key, whose subject is the unique identifier of the API key
+//
+// Returns:
+//
+//   - *gogrpcservercontext.Principal: the principal
+func (a APIKey) Principal() *gogrpcservercontext.Principal {
+	return &gogrpcservercontext.Principal{
+		Subject:    a.GetID(),
+		AuthScheme: gogrpcservercontext.AuthSchemeAPIKey,
+		Attributes: map[string]any{
+			"id":    a.GetID(),
+			"name":  a.Name,
+			"owner": a.Owner,
+		},
+	}
+}
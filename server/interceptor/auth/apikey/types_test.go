@@ -0,0 +1,39 @@
+package apikeys
+
+import (
+	"testing"
+
+	gogrpcservercontext "github.com/ralvarezdev/go-grpc/server/context"
+)
+
+func TestAPIKeyPrincipal(t *testing.T) {
+	tests := []struct {
+		name        string
+		apiKey      APIKey
+		wantSubject string
+	}{
+		{
+			name:        "identifier",
+			apiKey:      APIKey{ID: "key-1", Name: "billing", Owner: "team"},
+			wantSubject: "key-1",
+		},
+		{
+			name:        "name fallback",
+			apiKey:      APIKey{Name: "billing", Owner: "team"},
+			wantSubject: "billing",
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				principal := test.apiKey.Principal()
+				if principal.Subject != test.wantSubject || principal.AuthScheme != gogrpcservercontext.AuthSchemeAPIKey {
+					t.Fatalf("unexpected principal %+v", principal)
+				}
+				if principal.Attributes["name"] != "billing" || principal.Attributes["owner"] != "team" {
+					t.Fatalf("expected the name and owner attributes, got %v", principal.Attributes)
+				}
+			},
+		)
+	}
+}
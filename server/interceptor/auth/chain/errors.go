@@ -0,0 +1,13 @@
+package chain
+
+import (
+	"errors"
+)
+
+var (
+	ErrNilAuthenticators        = errors.New("authenticators map cannot be nil")
+	ErrNilAuthenticator         = errors.New("authenticator cannot be nil")
+	ErrAllAuthenticationsFailed = errors.New("all authentication schemes failed")
+	ErrAuthenticatorNotFound    = errors.New("authenticator not found")
+	ErrNilAuthenticatedContext  = errors.New("authenticator returned a nil context")
+)
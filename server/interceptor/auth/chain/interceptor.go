@@ -0,0 +1,223 @@
+package chain
+
+import (
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gogrpc "github.com/ralvarezdev/go-grpc"
+	gogrpcservercontext "github.com/ralvarezdev/go-grpc/server/context"
+	gogrpcauth "github.com/ralvarezdev/go-grpc/server/interceptor/auth"
+	gogrpcserverstream "github.com/ralvarezdev/go-grpc/server/stream"
+)
+
+type (
+	// Interceptor is the interceptor that chains several authentication schemes, succeeding on the first one that
+	// authenticates the caller
+	//
+	// A scheme only succeeds if its authenticator intercepts the method and sets the principal to the context
+	Interceptor struct {
+		authenticators map[gogrpcservercontext.AuthScheme]gogrpcauth.Authenticator
+		interceptions  *gogrpc.MethodMatcher[[]gogrpcservercontext.AuthScheme]
+		logger         *slog.Logger
+	}
+)
+
+// NewInterceptor creates a new chained authentication interceptor
+//
+// Parameters:
+//
+//   - authenticators: the authenticators keyed by their authentication scheme
+//   - interceptions: the method matcher of the authentication schemes to try, in order, for each method
+//   - logger: the logger to use (optional, can be nil)
+//
+// Returns:
+//
+//   - *Interceptor: the interceptor
+//   - error: if the authenticators or the interceptions are nil
+func NewInterceptor(
+	authenticators map[gogrpcservercontext.AuthScheme]gogrpcauth.Authenticator,
+	interceptions *gogrpc.MethodMatcher[[]gogrpcservercontext.AuthScheme],
+	logger *slog.Logger,
+) (*Interceptor, error) {
+	// Check if either the authenticators or the interceptions are nil
+	if authenticators == nil {
+		return nil, ErrNilAuthenticators
+	}
+	if interceptions == nil {
+		return nil, gogrpc.ErrNilInterceptions
+	}
+
+	// Copy the authenticators, so they cannot be modified after the creation
+	chainedAuthenticators := make(
+		map[gogrpcservercontext.AuthScheme]gogrpcauth.Authenticator,
+		len(authenticators),
+	)
+	for scheme, authenticator := range authenticators {
+		if authenticator == nil {
+			return nil, ErrNilAuthenticator
+		}
+		chainedAuthenticators[scheme] = authenticator
+	}
+
+	if logger != nil {
+		logger = logger.With(
+			slog.String("grpc_server_interceptor", "chain_authenticator"),
+		)
+	}
+
+	return &Interceptor{
+		authenticators: chainedAuthenticators,
+		interceptions:  interceptions,
+		logger:         logger,
+	}, nil
+}
+
+// authenticateScheme authenticates the request with the given scheme
+//
+// Parameters:
+//
+//   - ctx: the context of the request
+//   - fullMethod: the full method name of the request
+//   - scheme: the authentication scheme to try
+//
+// Returns:
+//
+//   - context.Context: the authenticated context
+//   - error: if the authenticator failed, did not intercept the method or did not set the principal
+func (i Interceptor) authenticateScheme(
+	ctx context.Context,
+	fullMethod string,
+	scheme gogrpcservercontext.AuthScheme,
+) (context.Context, error) {
+	authenticator, ok := i.authenticators[scheme]
+	if !ok {
+		return nil, ErrAuthenticatorNotFound
+	}
+
+	// Authenticate the request
+	authCtx, err := authenticator.AuthenticateCtx(ctx, fullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check that the authenticator actually authenticated the caller
+	if authCtx == nil {
+		return nil, ErrNilAuthenticatedContext
+	}
+	if _, err = gogrpcservercontext.GetPrincipal(authCtx); err != nil {
+		return nil, err
+	}
+	return authCtx, nil
+}
+
+// Intercepts checks if the given method is intercepted by at least one authentication scheme
+//
+// Parameters:
+//
+//   - fullMethod: the full method name
+//
+// Returns:
+//
+//   - bool: true if the method is intercepted, false otherwise
+func (i Interceptor) Intercepts(fullMethod string) bool {
+	schemes, ok := i.interceptions.Match(fullMethod)
+	return ok && len(schemes) > 0
+}
+
+// authenticate tries the authentication schemes of the given method in order, falling through to the next scheme
+// only when a scheme did not authenticate the caller. Any other gRPC status error of a scheme, e.g. permission denied
+// or resource exhausted, is returned as it is
+//
+// Parameters:
+//
+//   - ctx: the context of the request
+//   - fullMethod: the full method name of the request
+//
+// Returns:
+//
+//   - context.Context: the authenticated context with the succeeded authentication scheme set
+//   - error: the gRPC status error of a scheme that is not unauthenticated, or an unauthenticated gRPC status error if
+//     every authentication scheme failed
+func (i Interceptor) authenticate(
+	ctx context.Context,
+	fullMethod string,
+) (context.Context, error) {
+	// Check if the method should be intercepted
+	if !i.Intercepts(fullMethod) {
+		return ctx, nil
+	}
+	schemes, _ := i.interceptions.Match(fullMethod)
+
+	// Try each authentication scheme in order
+	for _, scheme := range schemes {
+		authCtx, err := i.authenticateScheme(ctx, fullMethod, scheme)
+		if err == nil {
+			return gogrpcservercontext.SetCtxAuthScheme(authCtx, scheme), nil
+		}
+
+		// Return the gRPC status errors other than unauthenticated, so they are not hidden by the next schemes
+		if st, isStatus := status.FromError(err); isStatus && st.Code() != codes.Unauthenticated {
+			return nil, err
+		}
+
+		if i.logger != nil {
+			i.logger.Debug(
+				"Authentication scheme failed",
+				slog.String("method", fullMethod),
+				slog.String("scheme", scheme.String()),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+	return nil, status.Error(
+		codes.Unauthenticated,
+		ErrAllAuthenticationsFailed.Error(),
+	)
+}
+
+// Authenticate returns the chained authentication interceptor
+//
+// Returns:
+//
+//   - grpc.UnaryServerInterceptor: the chained authentication interceptor
+func (i Interceptor) Authenticate() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req any, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		// Authenticate the request
+		ctx, err := i.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AuthenticateStream returns the chained stream authentication interceptor
+//
+// Returns:
+//
+//   - grpc.StreamServerInterceptor: the chained stream authentication interceptor
+func (i Interceptor) AuthenticateStream() grpc.StreamServerInterceptor {
+	return func(
+		srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		// Authenticate the stream
+		ctx, err := i.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		// Wrap the server stream with the authenticated context
+		return handler(
+			srv,
+			gogrpcserverstream.NewWrappedServerStream(ctx, ss),
+		)
+	}
+}
@@ -0,0 +1,268 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	gogrpc "github.com/ralvarezdev/go-grpc"
+	gogrpcservercontext "github.com/ralvarezdev/go-grpc/server/context"
+	gogrpcauth "github.com/ralvarezdev/go-grpc/server/interceptor/auth"
+	gogrpcapikey "github.com/ralvarezdev/go-grpc/server/interceptor/auth/apikey"
+)
+
+const (
+	testMethod = "/pkg.Service/Method"
+	testAPIKey = "secret"
+)
+
+type (
+	// stubAPIKeyService accepts only the test API key
+	stubAPIKeyService struct{}
+
+	// stubAuthenticator returns the given context and error
+	stubAuthenticator struct {
+		ctx func(ctx context.Context) context.Context
+		err error
+	}
+)
+
+func (stubAPIKeyService) IsAPIKeyValid(apiKey string) bool {
+	return apiKey == testAPIKey
+}
+
+func (s stubAuthenticator) AuthenticateCtx(ctx context.Context, _ string) (context.Context, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.ctx(ctx), nil
+}
+
+// newAPIKeyAuthenticator creates an API key authenticator that intercepts the given methods
+func newAPIKeyAuthenticator(t *testing.T, methods ...string) gogrpcauth.Authenticator {
+	t.Helper()
+
+	var interceptions *gogrpc.MethodMatcher[struct{}]
+	if len(methods) > 0 {
+		var err error
+		if interceptions, err = gogrpc.NewMethodSetMatcher(methods); err != nil {
+			t.Fatalf("NewMethodSetMatcher: %v", err)
+		}
+	}
+	authenticator, err := gogrpcapikey.NewInterceptor(stubAPIKeyService{}, interceptions, nil)
+	if err != nil {
+		t.Fatalf("NewInterceptor: %v", err)
+	}
+	return authenticator
+}
+
+// authenticate runs the chain for the test method and returns the context reaching the handler
+func authenticate(
+	t *testing.T,
+	authenticators map[gogrpcservercontext.AuthScheme]gogrpcauth.Authenticator,
+	ctx context.Context,
+) (context.Context, error) {
+	t.Helper()
+
+	interceptions, err := gogrpc.NewMethodMatcher(
+		map[string][]gogrpcservercontext.AuthScheme{
+			testMethod: {
+				gogrpcservercontext.AuthSchemeAPIKey,
+				gogrpcservercontext.AuthSchemeJWT,
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("NewMethodMatcher: %v", err)
+	}
+	interceptor, err := NewInterceptor(authenticators, interceptions, nil)
+	if err != nil {
+		t.Fatalf("NewInterceptor: %v", err)
+	}
+
+	var handlerCtx context.Context
+	_, err = interceptor.Authenticate()(
+		ctx,
+		nil,
+		&grpc.UnaryServerInfo{FullMethod: testMethod},
+		func(innerCtx context.Context, _ any) (any, error) {
+			handlerCtx = innerCtx
+			return nil, nil
+		},
+	)
+	return handlerCtx, err
+}
+
+func TestAuthenticateValidAPIKey(t *testing.T) {
+	ctx := metadata.NewIncomingContext(
+		context.Background(),
+		metadata.Pairs(gogrpc.AuthorizationMetadataKey, "Bearer "+testAPIKey),
+	)
+	handlerCtx, err := authenticate(
+		t,
+		map[gogrpcservercontext.AuthScheme]gogrpcauth.Authenticator{
+			gogrpcservercontext.AuthSchemeAPIKey: newAPIKeyAuthenticator(t, testMethod),
+			gogrpcservercontext.AuthSchemeJWT:    stubAuthenticator{err: errors.New("no token")},
+		},
+		ctx,
+	)
+	if err != nil {
+		t.Fatalf("expected the API key to authenticate, got %v", err)
+	}
+	scheme, err := gogrpcservercontext.GetCtxAuthScheme(handlerCtx)
+	if err != nil || scheme != gogrpcservercontext.AuthSchemeAPIKey {
+		t.Fatalf("expected the API key scheme, got %q (%v)", scheme, err)
+	}
+}
+
+func TestAuthenticateFallsBackToNextScheme(t *testing.T) {
+	handlerCtx, err := authenticate(
+		t,
+		map[gogrpcservercontext.AuthScheme]gogrpcauth.Authenticator{
+			gogrpcservercontext.AuthSchemeAPIKey: newAPIKeyAuthenticator(t, testMethod),
+			gogrpcservercontext.AuthSchemeJWT: stubAuthenticator{
+				ctx: func(ctx context.Context) context.Context {
+					return gogrpcservercontext.SetCtxPrincipal(
+						ctx,
+						&gogrpcservercontext.Principal{
+							Subject:    "user",
+							AuthScheme: gogrpcservercontext.AuthSchemeJWT,
+						},
+					)
+				},
+			},
+		},
+		context.Background(),
+	)
+	if err != nil {
+		t.Fatalf("expected the JWT scheme to authenticate, got %v", err)
+	}
+	scheme, err := gogrpcservercontext.GetCtxAuthScheme(handlerCtx)
+	if err != nil || scheme != gogrpcservercontext.AuthSchemeJWT {
+		t.Fatalf("expected the JWT scheme, got %q (%v)", scheme, err)
+	}
+}
+
+func TestAuthenticateRejectsPassThrough(t *testing.T) {
+	tests := []struct {
+		name          string
+		authenticator gogrpcauth.Authenticator
+	}{
+		{
+			name:          "authenticator without interceptions",
+			authenticator: newAPIKeyAuthenticator(t),
+		},
+		{
+			name:          "authenticator not intercepting the method",
+			authenticator: newAPIKeyAuthenticator(t, "/pkg.Service/Other"),
+		},
+		{
+			name: "authenticator without principal",
+			authenticator: stubAuthenticator{
+				ctx: func(ctx context.Context) context.Context {
+					return ctx
+				},
+			},
+		},
+		{
+			name: "authenticator with nil context",
+			authenticator: stubAuthenticator{
+				ctx: func(context.Context) context.Context {
+					return nil
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				_, err := authenticate(
+					t,
+					map[gogrpcservercontext.AuthScheme]gogrpcauth.Authenticator{
+						gogrpcservercontext.AuthSchemeAPIKey: test.authenticator,
+						gogrpcservercontext.AuthSchemeJWT: stubAuthenticator{
+							err: errors.New("no token"),
+						},
+					},
+					context.Background(),
+				)
+				if status.Code(err) != codes.Unauthenticated {
+					t.Fatalf("expected Unauthenticated, got %v", err)
+				}
+			},
+		)
+	}
+}
+
+func TestAuthenticateReturnsOtherStatuses(t *testing.T) {
+	for _, code := range []codes.Code{
+		codes.PermissionDenied,
+		codes.ResourceExhausted,
+		codes.Internal,
+	} {
+		t.Run(
+			code.String(), func(t *testing.T) {
+				_, err := authenticate(
+					t,
+					map[gogrpcservercontext.AuthScheme]gogrpcauth.Authenticator{
+						gogrpcservercontext.AuthSchemeAPIKey: stubAuthenticator{
+							err: status.Error(code, "scheme failed"),
+						},
+						gogrpcservercontext.AuthSchemeJWT: stubAuthenticator{
+							ctx: func(ctx context.Context) context.Context {
+								return gogrpcservercontext.SetCtxPrincipal(
+									ctx,
+									&gogrpcservercontext.Principal{
+										Subject:    "user",
+										AuthScheme: gogrpcservercontext.AuthSchemeJWT,
+									},
+								)
+							},
+						},
+					},
+					context.Background(),
+				)
+				st := status.Convert(err)
+				if st.Code() != code || st.Message() != "scheme failed" {
+					t.Fatalf("expected the %v status of the scheme, got %v", code, err)
+				}
+			},
+		)
+	}
+}
+
+func TestAuthenticateFallsThroughUnauthenticated(t *testing.T) {
+	_, err := authenticate(
+		t,
+		map[gogrpcservercontext.AuthScheme]gogrpcauth.Authenticator{
+			gogrpcservercontext.AuthSchemeAPIKey: stubAuthenticator{
+				err: status.Error(codes.Unauthenticated, "invalid API key"),
+			},
+			gogrpcservercontext.AuthSchemeJWT: stubAuthenticator{
+				err: status.Error(codes.PermissionDenied, "token revoked"),
+			},
+		},
+		context.Background(),
+	)
+	if st := status.Convert(err); st.Code() != codes.PermissionDenied || st.Message() != "token revoked" {
+		t.Fatalf("expected the permission denied status of the next scheme, got %v", err)
+	}
+}
+
+func TestNewInterceptorNilAuthenticator(t *testing.T) {
+	_, err := NewInterceptor(
+		map[gogrpcservercontext.AuthScheme]gogrpcauth.Authenticator{
+			gogrpcservercontext.AuthSchemeJWT: nil,
+		},
+		&gogrpc.MethodMatcher[[]gogrpcservercontext.AuthScheme]{},
+		nil,
+	)
+	if !errors.Is(err, ErrNilAuthenticator) {
+		t.Fatalf("expected ErrNilAuthenticator, got %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package gcloud
+
+import (
+	"time"
+
+	gogrpcservercontext "github.com/ralvarezdev/go-grpc/server/context"
+)
+
+const (
+	// GoogleJWKSURL is the URL of the Google OAuth2 JSON Web Key Set used to sign the ID tokens
+	GoogleJWKSURL = "https://www.googleapis.com/oauth2/v3/certs"
+
+	// DefaultRefreshInterval is the default interval to refresh a JSON Web Key Set fetched from a URL
+	DefaultRefreshInterval = time.Hour
+
+	// MinRefreshInterval is the minimum interval between two refreshes of a JSON Web Key Set fetched from a URL
+	MinRefreshInterval = time.Minute
+
+	// ReloadTimeout is the timeout of a reload of a JSON Web Key Set, which is not bound to the context of the caller
+	// that started it since it is shared by the concurrent callers
+	ReloadTimeout = 30 * time.Second
+
+	// KeyIDHeader is the header of the token with the ID of the signing key
+	KeyIDHeader = "kid"
+
+	// EmailClaim is the claim with the email of the service account
+	EmailClaim = "email"
+
+	// EmailVerifiedClaim is the claim that states if the email has been verified
+	EmailVerifiedClaim = "email_verified"
+)
+
+var (
+	// GoogleIssuers are the issuers of the Google ID tokens
+	GoogleIssuers = []string{"https://accounts.google.com", "accounts.google.com"}
+
+	// ValidSigningMethods are the signing methods accepted for the ID tokens
+	ValidSigningMethods = []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}
+
+	// CtxEmailKey is the key for the verified service account email to be set to the context
+	CtxEmailKey gogrpcservercontext.CtxKey = "gcloud_email"
+)
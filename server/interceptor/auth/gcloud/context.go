@@ -0,0 +1,44 @@
+package gcloud
+
+import (
+	"context"
+)
+
+// SetCtxEmail sets the verified service account email to the context
+//
+// Parameters:
+//
+//   - ctx: The context to set the email to
+//   - email: The email to set
+//
+// Returns:
+//
+//   - context.Context: The context with the email set
+func SetCtxEmail(ctx context.Context, email string) context.Context {
+	return context.WithValue(ctx, CtxEmailKey, email)
+}
+
+// GetCtxEmail gets the verified service account email from the context
+//
+// Parameters:
+//
+//   - ctx: The context to get the email from
+//
+// Returns:
+//
+//   - string: The email
+//   - error: An error if the email is not found or is of an unexpected type
+func GetCtxEmail(ctx context.Context) (string, error) {
+	// Get the email from the context
+	value := ctx.Value(CtxEmailKey)
+	if value == nil {
+		return "", ErrMissingEmailInContext
+	}
+
+	// Check the type of the value
+	email, ok := value.(string)
+	if !ok {
+		return "", ErrUnexpectedEmailTypeInContext
+	}
+	return email, nil
+}
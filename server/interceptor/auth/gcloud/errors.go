@@ -0,0 +1,23 @@
+package gcloud
+
+import (
+	"errors"
+)
+
+var (
+	ErrNilKeySet                    = errors.New("key set cannot be nil")
+	ErrNilOptions                   = errors.New("options cannot be nil")
+	ErrEmptyAudiences               = errors.New("at least one audience is required")
+	ErrEmptyJWKSPath                = errors.New("JSON Web Key Set path cannot be empty")
+	ErrEmptyJWKSURL                 = errors.New("JSON Web Key Set URL cannot be empty")
+	ErrUnexpectedJWKSStatusCode     = errors.New("unexpected JSON Web Key Set response status code")
+	ErrKeyNotFound                  = errors.New("signing key not found")
+	ErrUnsupportedKeyType           = errors.New("unsupported key type")
+	ErrUnsupportedCurve             = errors.New("unsupported elliptic curve")
+	ErrInvalidKey                   = errors.New("invalid key")
+	ErrInvalidIssuer                = errors.New("invalid token issuer")
+	ErrMissingEmail                 = errors.New("missing token email")
+	ErrEmailNotVerified             = errors.New("token email is not verified")
+	ErrMissingEmailInContext        = errors.New("missing email in context")
+	ErrUnexpectedEmailTypeInContext = errors.New("unexpected email type in context")
+)
@@ -0,0 +1,467 @@
+package gcloud
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	gogrpc "github.com/ralvarezdev/go-grpc"
+)
+
+const (
+	testAudience = "https://service.example.com"
+	testEmail    = "caller@project.iam.gserviceaccount.com"
+	testMethod   = "/pkg.Service/Method"
+)
+
+type (
+	// testKeySet is a locally generated key set
+	testKeySet struct {
+		rsaKey *rsa.PrivateKey
+		ecKey  *ecdsa.PrivateKey
+		jwks   []byte
+	}
+
+	// testJWKSServer serves a JSON Web Key Set and counts the fetches
+	testJWKSServer struct {
+		*httptest.Server
+		fetches atomic.Int64
+		failing atomic.Bool
+		block   atomic.Pointer[chan struct{}]
+	}
+)
+
+// newTestKeySet generates an RSA and an EC key and their JSON Web Key Set
+func newTestKeySet(t *testing.T) *testKeySet {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey: %v", err)
+	}
+	point, err := ecKey.PublicKey.Bytes()
+	if err != nil {
+		t.Fatalf("PublicKey.Bytes: %v", err)
+	}
+
+	encode := base64.RawURLEncoding.EncodeToString
+	jwks, err := json.Marshal(
+		jsonWebKeySet{
+			Keys: []jsonWebKey{
+				{
+					KeyType: "RSA",
+					KeyID:   "rsa",
+					N:       encode(rsaKey.N.Bytes()),
+					E:       encode(big.NewInt(int64(rsaKey.E)).Bytes()),
+				},
+				{
+					KeyType: "EC",
+					KeyID:   "ec",
+					Curve:   "P-256",
+					X:       encode(point[1:33]),
+					Y:       encode(point[33:]),
+				},
+				{
+					KeyType: "oct",
+					KeyID:   "unsupported",
+				},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	return &testKeySet{rsaKey: rsaKey, ecKey: ecKey, jwks: jwks}
+}
+
+// sign signs the given claims with the key of the given key ID
+func (k *testKeySet) sign(t *testing.T, keyID string, claims jwt.MapClaims) string {
+	t.Helper()
+
+	var token *jwt.Token
+	var key any
+	switch keyID {
+	case "ec":
+		token, key = jwt.NewWithClaims(jwt.SigningMethodES256, claims), k.ecKey
+	default:
+		token, key = jwt.NewWithClaims(jwt.SigningMethodRS256, claims), k.rsaKey
+	}
+	token.Header[KeyIDHeader] = keyID
+
+	rawToken, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString: %v", err)
+	}
+	return rawToken
+}
+
+// newClaims creates valid ID token claims
+func newClaims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":            GoogleIssuers[0],
+		"aud":            testAudience,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"email":          testEmail,
+		"email_verified": true,
+	}
+}
+
+// newTestJWKSServer creates a server of the given JSON Web Key Set
+func newTestJWKSServer(t *testing.T, jwks []byte) *testJWKSServer {
+	t.Helper()
+
+	server := &testJWKSServer{}
+	server.Server = httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				server.fetches.Add(1)
+				if block := server.block.Load(); block != nil {
+					<-*block
+				}
+				if server.failing.Load() {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				_, _ = w.Write(jwks)
+			},
+		),
+	)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newTestInterceptor creates an interceptor for the test method with the given key set
+func newTestInterceptor(t *testing.T, keySet KeySet) *Interceptor {
+	t.Helper()
+
+	interceptions, err := gogrpc.NewMethodSetMatcher([]string{testMethod})
+	if err != nil {
+		t.Fatalf("NewMethodSetMatcher: %v", err)
+	}
+	interceptor, err := NewInterceptor(
+		keySet,
+		interceptions,
+		&Options{Audiences: []string{testAudience}},
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("NewInterceptor: %v", err)
+	}
+	return interceptor
+}
+
+func TestParseJWKS(t *testing.T) {
+	keySet := newTestKeySet(t)
+	keys, err := ParseJWKS(keySet.jwks, nil)
+	if err != nil {
+		t.Fatalf("ParseJWKS: %v", err)
+	}
+	if len(keys) != 2 {
+		t.Fatalf("expected the RSA and EC keys, got %d keys", len(keys))
+	}
+	if rsaKey, ok := keys["rsa"].(*rsa.PublicKey); !ok || !rsaKey.Equal(&keySet.rsaKey.PublicKey) {
+		t.Fatal("expected the RSA public key")
+	}
+	if ecKey, ok := keys["ec"].(*ecdsa.PublicKey); !ok || !ecKey.Equal(&keySet.ecKey.PublicKey) {
+		t.Fatal("expected the EC public key")
+	}
+}
+
+func TestNewFileJWKS(t *testing.T) {
+	keySet := newTestKeySet(t)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, keySet.jwks, 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	jwks, err := NewFileJWKS(path, nil)
+	if err != nil {
+		t.Fatalf("NewFileJWKS: %v", err)
+	}
+	if _, err = jwks.GetKey(context.Background(), "ec"); err != nil {
+		t.Fatalf("GetKey: %v", err)
+	}
+	if _, err = NewFileJWKS("", nil); !errors.Is(err, ErrEmptyJWKSPath) {
+		t.Fatalf("expected ErrEmptyJWKSPath, got %v", err)
+	}
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	keySet := newTestKeySet(t)
+	jwks, err := NewURLJWKS(
+		context.Background(),
+		newTestJWKSServer(t, keySet.jwks).URL,
+		0,
+		nil,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("NewURLJWKS: %v", err)
+	}
+	interceptor := newTestInterceptor(t, jwks)
+
+	tests := []struct {
+		name    string
+		keyID   string
+		modify  func(claims jwt.MapClaims)
+		wantErr error
+	}{
+		{
+			name:  "valid RSA token",
+			keyID: "rsa",
+		},
+		{
+			name:  "valid EC token",
+			keyID: "ec",
+		},
+		{
+			name:    "wrong audience",
+			keyID:   "rsa",
+			modify:  func(claims jwt.MapClaims) { claims["aud"] = "https://other.example.com" },
+			wantErr: jwt.ErrTokenInvalidAudience,
+		},
+		{
+			name:    "wrong issuer",
+			keyID:   "rsa",
+			modify:  func(claims jwt.MapClaims) { claims["iss"] = "https://issuer.example.com" },
+			wantErr: ErrInvalidIssuer,
+		},
+		{
+			name:    "expired",
+			keyID:   "rsa",
+			modify:  func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Hour).Unix() },
+			wantErr: jwt.ErrTokenExpired,
+		},
+		{
+			name:    "unverified email",
+			keyID:   "rsa",
+			modify:  func(claims jwt.MapClaims) { claims["email_verified"] = false },
+			wantErr: ErrEmailNotVerified,
+		},
+		{
+			name:    "missing email",
+			keyID:   "rsa",
+			modify:  func(claims jwt.MapClaims) { delete(claims, "email") },
+			wantErr: ErrMissingEmail,
+		},
+		{
+			name:    "unknown key ID",
+			keyID:   "unknown",
+			wantErr: ErrKeyNotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				claims := newClaims()
+				if test.modify != nil {
+					test.modify(claims)
+				}
+
+				email, _, verifyErr := interceptor.VerifyIDToken(
+					context.Background(),
+					keySet.sign(t, test.keyID, claims),
+				)
+				if test.wantErr != nil {
+					if !errors.Is(verifyErr, test.wantErr) {
+						t.Fatalf("expected %v, got %v", test.wantErr, verifyErr)
+					}
+					return
+				}
+				if verifyErr != nil {
+					t.Fatalf("VerifyIDToken: %v", verifyErr)
+				}
+				if email != testEmail {
+					t.Fatalf("expected email %q, got %q", testEmail, email)
+				}
+			},
+		)
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	keySet := newTestKeySet(t)
+	jwks, err := NewURLJWKS(
+		context.Background(),
+		newTestJWKSServer(t, keySet.jwks).URL,
+		0,
+		nil,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("NewURLJWKS: %v", err)
+	}
+	interceptor := newTestInterceptor(t, jwks)
+
+	call := func(rawToken string) (string, error) {
+		ctx := metadata.NewIncomingContext(
+			context.Background(),
+			metadata.Pairs(gogrpc.GCloudAuthorizationMetadataKey, "Bearer "+rawToken),
+		)
+		var email string
+		_, callErr := interceptor.Authenticate()(
+			ctx,
+			nil,
+			&grpc.UnaryServerInfo{FullMethod: testMethod},
+			func(innerCtx context.Context, _ any) (any, error) {
+				email, _ = GetCtxEmail(innerCtx)
+				return nil, nil
+			},
+		)
+		return email, callErr
+	}
+
+	email, err := call(keySet.sign(t, "rsa", newClaims()))
+	if err != nil {
+		t.Fatalf("expected the ID token to authenticate, got %v", err)
+	}
+	if email != testEmail {
+		t.Fatalf("expected email %q in the context, got %q", testEmail, email)
+	}
+
+	claims := newClaims()
+	claims["aud"] = "https://other.example.com"
+	if _, err = call(keySet.sign(t, "rsa", claims)); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated, got %v", err)
+	}
+}
+
+func TestGetKeyUnknownKeyIDSingleFlight(t *testing.T) {
+	keySet := newTestKeySet(t)
+	server := newTestJWKSServer(t, keySet.jwks)
+	jwks, err := NewURLJWKS(context.Background(), server.URL, 0, nil, nil)
+	if err != nil {
+		t.Fatalf("NewURLJWKS: %v", err)
+	}
+
+	// Let the next reload be attempted and block it until every request is waiting
+	jwks.mutex.Lock()
+	jwks.attemptedAt = time.Time{}
+	jwks.mutex.Unlock()
+	block := make(chan struct{})
+	server.block.Store(&block)
+
+	const requests = 20
+	var wg sync.WaitGroup
+	for range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, getErr := jwks.GetKey(context.Background(), "unknown"); !errors.Is(getErr, ErrKeyNotFound) {
+				t.Errorf("expected ErrKeyNotFound, got %v", getErr)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(block)
+	wg.Wait()
+
+	if fetches := server.fetches.Load(); fetches != 2 {
+		t.Fatalf("expected the initial fetch and a single reload, got %d fetches", fetches)
+	}
+
+	// The reload was attempted recently, so the next unknown key IDs do not fetch again
+	for range requests {
+		if _, err = jwks.GetKey(context.Background(), "unknown"); !errors.Is(err, ErrKeyNotFound) {
+			t.Fatalf("expected ErrKeyNotFound, got %v", err)
+		}
+	}
+	if fetches := server.fetches.Load(); fetches != 2 {
+		t.Fatalf("expected no more fetches, got %d fetches", fetches)
+	}
+}
+
+func TestGetKeyFailedReloadIsThrottled(t *testing.T) {
+	keySet := newTestKeySet(t)
+	server := newTestJWKSServer(t, keySet.jwks)
+	jwks, err := NewURLJWKS(context.Background(), server.URL, time.Nanosecond, nil, nil)
+	if err != nil {
+		t.Fatalf("NewURLJWKS: %v", err)
+	}
+
+	// Make the keys stale and the source unavailable
+	server.failing.Store(true)
+	jwks.mutex.Lock()
+	jwks.attemptedAt = time.Time{}
+	jwks.mutex.Unlock()
+
+	for range 10 {
+		// The known keys keep being served during the outage
+		if _, err = jwks.GetKey(context.Background(), "rsa"); err != nil {
+			t.Fatalf("expected the cached key, got %v", err)
+		}
+	}
+	if fetches := server.fetches.Load(); fetches != 2 {
+		t.Fatalf("expected the initial fetch and a single failed reload, got %d fetches", fetches)
+	}
+
+	// An explicit reload is not throttled
+	if err = jwks.Reload(context.Background()); err == nil {
+		t.Fatal("expected the explicit reload to fail")
+	}
+	if fetches := server.fetches.Load(); fetches != 3 {
+		t.Fatalf("expected the explicit reload to fetch, got %d fetches", fetches)
+	}
+}
+
+func TestReloadSurvivesCancelledCaller(t *testing.T) {
+	keySet := newTestKeySet(t)
+	server := newTestJWKSServer(t, keySet.jwks)
+	jwks, err := NewURLJWKS(context.Background(), server.URL, 0, nil, nil)
+	if err != nil {
+		t.Fatalf("NewURLJWKS: %v", err)
+	}
+
+	// Block the reload started by a caller that is cancelled while another caller waits for it
+	block := make(chan struct{})
+	server.block.Store(&block)
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		firstErr <- jwks.Reload(ctx)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	secondErr := make(chan error, 1)
+	go func() {
+		secondErr <- jwks.Reload(context.Background())
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	cancel()
+	if err = <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the cancelled caller to return context.Canceled, got %v", err)
+	}
+	close(block)
+	if err = <-secondErr; err != nil {
+		t.Fatalf("expected the shared reload to succeed for the other caller, got %v", err)
+	}
+	if fetches := server.fetches.Load(); fetches != 2 {
+		t.Fatalf("expected the initial fetch and a single reload, got %d fetches", fetches)
+	}
+}
@@ -0,0 +1,275 @@
+package gcloud
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gogrpc "github.com/ralvarezdev/go-grpc"
+	gogrpcmd "github.com/ralvarezdev/go-grpc/metadata"
+	gogrpcservercontext "github.com/ralvarezdev/go-grpc/server/context"
+	gogrpcserverstream "github.com/ralvarezdev/go-grpc/server/stream"
+)
+
+type (
+	// Interceptor is the interceptor for the Google Cloud ID token authentication
+	Interceptor struct {
+		keySet           KeySet
+		interceptions    *gogrpc.MethodMatcher[struct{}]
+		issuers          []string
+		principalOptions *gogrpcservercontext.PrincipalOptions
+		parser           *jwt.Parser
+		logger           *slog.Logger
+	}
+)
+
+// NewInterceptor creates a new Google Cloud ID token authentication interceptor
+//
+// Parameters:
+//
+//   - keySet: the key set to verify the ID tokens signatures
+//   - interceptions: the method matcher to determine which methods require authentication
+//   - options: the options for the interceptor
+//   - logger: the logger to use (optional, can be nil)
+//
+// Returns:
+//
+//   - *Interceptor: the interceptor
+//   - error: if the key set, the interceptions or the options are nil, or no audience is given
+func NewInterceptor(
+	keySet KeySet,
+	interceptions *gogrpc.MethodMatcher[struct{}],
+	options *Options,
+	logger *slog.Logger,
+) (*Interceptor, error) {
+	// Check if either the key set, the interceptions or the options are nil
+	if keySet == nil {
+		return nil, ErrNilKeySet
+	}
+	if interceptions == nil {
+		return nil, gogrpc.ErrNilInterceptions
+	}
+	if options == nil {
+		return nil, ErrNilOptions
+	}
+
+	// Check if there is at least one audience
+	if len(options.Audiences) == 0 {
+		return nil, ErrEmptyAudiences
+	}
+
+	// Set the default issuers
+	issuers := options.Issuers
+	if len(issuers) == 0 {
+		issuers = GoogleIssuers
+	}
+
+	if logger != nil {
+		logger = logger.With(
+			slog.String("grpc_server_interceptor", "gcloud_authenticator"),
+		)
+	}
+
+	return &Interceptor{
+		keySet:           keySet,
+		interceptions:    interceptions,
+		issuers:          issuers,
+		principalOptions: options.PrincipalOptions,
+		parser: jwt.NewParser(
+			jwt.WithValidMethods(ValidSigningMethods),
+			jwt.WithAudience(options.Audiences...),
+			jwt.WithExpirationRequired(),
+			jwt.WithIssuedAt(),
+			jwt.WithLeeway(options.Leeway),
+		),
+		logger: logger,
+	}, nil
+}
+
+// VerifyIDToken verifies the signature, audience, issuer and expiration of an ID token
+//
+// Parameters:
+//
+//   - ctx: the context used to get the signing key
+//   - rawToken: the raw ID token
+//
+// Returns:
+//
+//   - string: the verified email of the token
+//   - jwt.MapClaims: the token claims
+//   - error: if the ID token is invalid
+func (i Interceptor) VerifyIDToken(ctx context.Context, rawToken string) (
+	string,
+	jwt.MapClaims,
+	error,
+) {
+	// Parse the token and verify its signature, audience and expiration
+	claims := jwt.MapClaims{}
+	if _, err := i.parser.ParseWithClaims(
+		rawToken,
+		claims,
+		func(token *jwt.Token) (any, error) {
+			keyID, _ := token.Header[KeyIDHeader].(string)
+			return i.keySet.GetKey(ctx, keyID)
+		},
+	); err != nil {
+		return "", nil, err
+	}
+
+	// Check the issuer
+	issuer, err := claims.GetIssuer()
+	if err != nil || !slices.Contains(i.issuers, issuer) {
+		return "", nil, ErrInvalidIssuer
+	}
+
+	// Get the email, which must be verified if the claim is present
+	email, ok := claims[EmailClaim].(string)
+	if !ok || email == "" {
+		return "", nil, ErrMissingEmail
+	}
+	if emailVerified, found := claims[EmailVerifiedClaim]; found {
+		if verified, isBool := emailVerified.(bool); !isBool || !verified {
+			return "", nil, ErrEmailNotVerified
+		}
+	}
+	return email, claims, nil
+}
+
+// authenticate verifies the ID token of the given method
+//
+// Parameters:
+//
+//   - ctx: the context of the request
+//   - fullMethod: the full method name of the request
+//
+// Returns:
+//
+//   - context.Context: the context with the email and the principal set, if the method is intercepted
+//   - error: a gRPC status error if the authentication failed
+func (i Interceptor) authenticate(
+	ctx context.Context,
+	fullMethod string,
+) (context.Context, error) {
+	// Check if the method should be intercepted
+	if _, ok := i.interceptions.Match(fullMethod); !ok {
+		return ctx, nil
+	}
+
+	// Get the raw ID token from the metadata
+	rawToken, err := gogrpcmd.GetIncomingCtxMetadataGCloudAuthorizationToken(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	// Verify the ID token
+	email, claims, err := i.VerifyIDToken(ctx, rawToken)
+	if err != nil {
+		if i.logger != nil {
+			i.logger.Debug(
+				"Failed to verify ID token",
+				slog.String("method", fullMethod),
+				slog.String("error", err.Error()),
+			)
+		}
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	// Set the email and the principal to the context
+	ctx = SetCtxEmail(ctx, email)
+	ctx = gogrpcservercontext.SetCtxPrincipal(
+		ctx,
+		gogrpcservercontext.NewClaimsPrincipal(
+			gogrpcservercontext.AuthSchemeGCloud,
+			email,
+			claims,
+			i.principalOptions,
+		),
+	)
+	return ctx, nil
+}
+
+// Intercepts checks if the given method is intercepted
+//
+// Parameters:
+//
+//   - fullMethod: the full method name
+//
+// Returns:
+//
+//   - bool: true if the method is intercepted, false otherwise
+func (i Interceptor) Intercepts(fullMethod string) bool {
+	_, ok := i.interceptions.Match(fullMethod)
+	return ok
+}
+
+// AuthenticateCtx authenticates a request of the given method without passing through the methods that are not
+// intercepted
+//
+// Parameters:
+//
+//   - ctx: the context of the request
+//   - fullMethod: the full method name of the request
+//
+// Returns:
+//
+//   - context.Context: the context with the email and principal set
+//   - error: gogrpc.ErrMethodNotIntercepted if the method is not intercepted, or a gRPC status error if the
+//     authentication failed
+func (i Interceptor) AuthenticateCtx(
+	ctx context.Context,
+	fullMethod string,
+) (context.Context, error) {
+	// Check if the method is intercepted
+	if !i.Intercepts(fullMethod) {
+		return nil, gogrpc.ErrMethodNotIntercepted
+	}
+	return i.authenticate(ctx, fullMethod)
+}
+
+// Authenticate returns the Google Cloud ID token authentication interceptor
+//
+// Returns:
+//
+//   - grpc.UnaryServerInterceptor: the interceptor
+func (i Interceptor) Authenticate() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req any, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		// Authenticate the request
+		ctx, err := i.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AuthenticateStream returns the Google Cloud ID token stream authentication interceptor
+//
+// Returns:
+//
+//   - grpc.StreamServerInterceptor: the interceptor
+func (i Interceptor) AuthenticateStream() grpc.StreamServerInterceptor {
+	return func(
+		srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		// Authenticate the stream
+		ctx, err := i.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		// Wrap the server stream with the authenticated context
+		return handler(
+			srv,
+			gogrpcserverstream.NewWrappedServerStream(ctx, ss),
+		)
+	}
+}
@@ -0,0 +1,13 @@
+package gcloud
+
+import (
+	"context"
+	"crypto"
+)
+
+type (
+	// KeySet is the interface for the sets of public keys used to verify the ID tokens
+	KeySet interface {
+		GetKey(ctx context.Context, keyID string) (crypto.PublicKey, error)
+	}
+)
@@ -0,0 +1,441 @@
+package gcloud
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+type (
+	// jsonWebKey is a JSON Web Key as defined in RFC 7517
+	jsonWebKey struct {
+		KeyType string `json:"kty"`
+		KeyID   string `json:"kid"`
+		N       string `json:"n"`
+		E       string `json:"e"`
+		Curve   string `json:"crv"`
+		X       string `json:"x"`
+		Y       string `json:"y"`
+	}
+
+	// jsonWebKeySet is a JSON Web Key Set as defined in RFC 7517
+	jsonWebKeySet struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+
+	// jwksReload is an in-flight reload of a JSON Web Key Set
+	jwksReload struct {
+		done chan struct{}
+		err  error
+	}
+
+	// JWKS is a JSON Web Key Set loaded from a file or fetched from a URL
+	//
+	// The reloads are shared by the concurrent callers and, unless explicitly requested, attempted at most once per
+	// MinRefreshInterval, even if they fail
+	JWKS struct {
+		loadFn          func(ctx context.Context) ([]byte, error)
+		refreshInterval time.Duration
+		mutex           sync.RWMutex
+		keys            map[string]crypto.PublicKey
+		loadedAt        time.Time
+		attemptedAt     time.Time
+		inFlight        *jwksReload
+		logger          *slog.Logger
+	}
+)
+
+// decodeBase64URL decodes a base64url encoded value, with or without padding
+//
+// Parameters:
+//
+//   - value: the value to decode
+//
+// Returns:
+//
+//   - []byte: the decoded value
+//   - error: if the value could not be decoded
+func decodeBase64URL(value string) ([]byte, error) {
+	if decoded, err := base64.RawURLEncoding.DecodeString(value); err == nil {
+		return decoded, nil
+	}
+	return base64.URLEncoding.DecodeString(value)
+}
+
+// publicKey parses the public key of the JSON Web Key
+//
+// Returns:
+//
+//   - crypto.PublicKey: the public key
+//   - error: if the key type or curve is unsupported or the key is invalid
+func (j jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch j.KeyType {
+	case "RSA":
+		n, err := decodeBase64URL(j.N)
+		if err != nil || len(n) == 0 {
+			return nil, ErrInvalidKey
+		}
+		e, err := decodeBase64URL(j.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, ErrInvalidKey
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch j.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, ErrUnsupportedCurve
+		}
+		x, err := decodeBase64URL(j.X)
+		if err != nil {
+			return nil, ErrInvalidKey
+		}
+		y, err := decodeBase64URL(j.Y)
+		if err != nil {
+			return nil, ErrInvalidKey
+		}
+
+		// Build the uncompressed point
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) > size || len(y) > size {
+			return nil, ErrInvalidKey
+		}
+		point := make([]byte, 1+2*size)
+		point[0] = 4
+		copy(point[1+size-len(x):1+size], x)
+		copy(point[1+2*size-len(y):], y)
+		key, err := ecdsa.ParseUncompressedPublicKey(curve, point)
+		if err != nil {
+			return nil, ErrInvalidKey
+		}
+		return key, nil
+	default:
+		return nil, ErrUnsupportedKeyType
+	}
+}
+
+// ParseJWKS parses a JSON Web Key Set
+//
+// Parameters:
+//
+//   - data: the JSON Web Key Set
+//   - logger: the logger to use (optional, can be nil)
+//
+// Returns:
+//
+//   - map[string]crypto.PublicKey: the public keys keyed by their key ID, unsupported keys are skipped
+//   - error: if the JSON Web Key Set could not be parsed
+func ParseJWKS(data []byte, logger *slog.Logger) (
+	map[string]crypto.PublicKey,
+	error,
+) {
+	var set jsonWebKeySet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		key, err := jwk.publicKey()
+		if err != nil {
+			if logger != nil {
+				logger.Warn(
+					"Skipping JSON Web Key",
+					slog.String("key_id", jwk.KeyID),
+					slog.String("error", err.Error()),
+				)
+			}
+			continue
+		}
+		keys[jwk.KeyID] = key
+	}
+	return keys, nil
+}
+
+// newJWKS creates a new JSON Web Key Set and loads it
+//
+// Parameters:
+//
+//   - ctx: the context used to load the keys
+//   - loadFn: the function that loads the raw JSON Web Key Set
+//   - refreshInterval: the interval to reload the keys, if zero the keys are only reloaded on unknown key IDs
+//   - logger: the logger to use (optional, can be nil)
+//
+// Returns:
+//
+//   - *JWKS: the JSON Web Key Set
+//   - error: if the keys could not be loaded
+func newJWKS(
+	ctx context.Context,
+	loadFn func(ctx context.Context) ([]byte, error),
+	refreshInterval time.Duration,
+	logger *slog.Logger,
+) (*JWKS, error) {
+	if logger != nil {
+		logger = logger.With(
+			slog.String("key_set", "gcloud_jwks"),
+		)
+	}
+
+	j := &JWKS{
+		loadFn:          loadFn,
+		refreshInterval: refreshInterval,
+		logger:          logger,
+	}
+	if err := j.Reload(ctx); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// NewFileJWKS creates a new JSON Web Key Set loaded from a file
+//
+// Parameters:
+//
+//   - path: the path of the JSON Web Key Set file
+//   - logger: the logger to use (optional, can be nil)
+//
+// Returns:
+//
+//   - *JWKS: the JSON Web Key Set
+//   - error: if the path is empty or the keys could not be loaded
+func NewFileJWKS(path string, logger *slog.Logger) (*JWKS, error) {
+	// Check if the path is empty
+	if path == "" {
+		return nil, ErrEmptyJWKSPath
+	}
+
+	return newJWKS(
+		context.Background(),
+		func(ctx context.Context) ([]byte, error) {
+			return os.ReadFile(path)
+		},
+		0,
+		logger,
+	)
+}
+
+// NewURLJWKS creates a new JSON Web Key Set fetched from a URL
+//
+// Parameters:
+//
+//   - ctx: the context used to fetch the keys for the first time
+//   - url: the URL of the JSON Web Key Set, e.g. GoogleJWKSURL
+//   - refreshInterval: the interval to refetch the keys (optional, if zero DefaultRefreshInterval is used)
+//   - client: the HTTP client (optional, if nil http.DefaultClient is used)
+//   - logger: the logger to use (optional, can be nil)
+//
+// Returns:
+//
+//   - *JWKS: the JSON Web Key Set
+//   - error: if the URL is empty or the keys could not be fetched
+func NewURLJWKS(
+	ctx context.Context,
+	url string,
+	refreshInterval time.Duration,
+	client *http.Client,
+	logger *slog.Logger,
+) (*JWKS, error) {
+	// Check if the URL is empty
+	if url == "" {
+		return nil, ErrEmptyJWKSURL
+	}
+
+	// Set the defaults
+	if refreshInterval <= 0 {
+		refreshInterval = DefaultRefreshInterval
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return newJWKS(
+		ctx,
+		func(ctx context.Context) ([]byte, error) {
+			req, err := http.NewRequestWithContext(
+				ctx,
+				http.MethodGet,
+				url,
+				nil,
+			)
+			if err != nil {
+				return nil, err
+			}
+			res, err := client.Do(req)
+			if err != nil {
+				return nil, err
+			}
+			defer func(body io.ReadCloser) {
+				_ = body.Close()
+			}(res.Body)
+
+			if res.StatusCode != http.StatusOK {
+				return nil, fmt.Errorf(
+					"%w: %d",
+					ErrUnexpectedJWKSStatusCode,
+					res.StatusCode,
+				)
+			}
+			return io.ReadAll(res.Body)
+		},
+		refreshInterval,
+		logger,
+	)
+}
+
+// wait waits for the reload to finish
+//
+// Parameters:
+//
+//   - ctx: the context of the caller
+//
+// Returns:
+//
+//   - error: the error of the reload, or the context error if the context is done first
+func (r *jwksReload) wait(ctx context.Context) error {
+	select {
+	case <-r.done:
+		return r.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// load loads the keys of the JSON Web Key Set for the given reload, keeping the previous keys if it fails
+//
+// Parameters:
+//
+//   - ctx: the context used to load the keys
+//   - currentReload: the reload to finish
+func (j *JWKS) load(ctx context.Context, currentReload *jwksReload) {
+	var keys map[string]crypto.PublicKey
+	data, err := j.loadFn(ctx)
+	if err == nil {
+		keys, err = ParseJWKS(data, j.logger)
+	}
+
+	j.mutex.Lock()
+	if err == nil {
+		j.keys = keys
+		j.loadedAt = time.Now()
+	}
+	j.inFlight = nil
+	j.mutex.Unlock()
+
+	currentReload.err = err
+	close(currentReload.done)
+}
+
+// reload reloads the keys of the JSON Web Key Set, joining the in-flight reload if there is one
+//
+// The keys are loaded without the cancellation of the context of the caller that started the reload, and with their
+// own ReloadTimeout, so a cancelled caller does not fail the reload for the other callers
+//
+// Parameters:
+//
+//   - ctx: the context of the caller, whose values are used to load the keys
+//   - force: whether to reload the keys even if a reload was attempted within MinRefreshInterval
+//
+// Returns:
+//
+//   - error: if the keys could not be loaded, or the context error if the context is done first
+func (j *JWKS) reload(ctx context.Context, force bool) error {
+	j.mutex.Lock()
+	if inFlight := j.inFlight; inFlight != nil {
+		j.mutex.Unlock()
+		return inFlight.wait(ctx)
+	}
+	if !force && time.Since(j.attemptedAt) <= MinRefreshInterval {
+		j.mutex.Unlock()
+		return nil
+	}
+	currentReload := &jwksReload{done: make(chan struct{})}
+	j.inFlight = currentReload
+	j.attemptedAt = time.Now()
+	j.mutex.Unlock()
+
+	// Load the keys in the background, so every caller waits with its own context
+	go func() {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), ReloadTimeout)
+		defer cancel()
+		j.load(loadCtx, currentReload)
+	}()
+	return currentReload.wait(ctx)
+}
+
+// Reload reloads the keys of the JSON Web Key Set. Concurrent calls share a single load
+//
+// Parameters:
+//
+//   - ctx: the context used to load the keys
+//
+// Returns:
+//
+//   - error: if the keys could not be loaded
+func (j *JWKS) Reload(ctx context.Context) error {
+	return j.reload(ctx, true)
+}
+
+// GetKey gets the public key with the given key ID, reloading the keys if they are stale or the key ID is unknown
+//
+// Parameters:
+//
+//   - ctx: the context used to reload the keys
+//   - keyID: the key ID
+//
+// Returns:
+//
+//   - crypto.PublicKey: the public key
+//   - error: if the key was not found
+func (j *JWKS) GetKey(ctx context.Context, keyID string) (
+	crypto.PublicKey,
+	error,
+) {
+	j.mutex.RLock()
+	key, ok := j.keys[keyID]
+	stale := j.refreshInterval > 0 && time.Since(j.loadedAt) > j.refreshInterval
+	j.mutex.RUnlock()
+	if ok && !stale {
+		return key, nil
+	}
+
+	// Reload the keys if they are stale or the key is unknown
+	if err := j.reload(ctx, false); err != nil {
+		if j.logger != nil {
+			j.logger.Error(
+				"Failed to reload JSON Web Key Set",
+				slog.String("error", err.Error()),
+			)
+		}
+	} else {
+		j.mutex.RLock()
+		key, ok = j.keys[keyID]
+		j.mutex.RUnlock()
+	}
+
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	return key, nil
+}
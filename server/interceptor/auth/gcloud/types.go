@@ -0,0 +1,25 @@
+package gcloud
+
+import (
+	"time"
+
+	gogrpcservercontext "github.com/ralvarezdev/go-grpc/server/context"
+)
+
+type (
+	// Options are the options for the ID token authentication interceptor
+	Options struct {
+		// Audiences are the accepted audiences of the ID tokens, at least one is required
+		Audiences []string
+
+		// Issuers are the accepted issuers of the ID tokens, if empty GoogleIssuers is used
+		Issuers []string
+
+		// Leeway is the allowed clock skew when checking the expiration of the ID tokens
+		Leeway time.Duration
+
+		// PrincipalOptions are the claims used to get the roles and the tenant of the principal (optional, can be
+		// nil)
+		PrincipalOptions *gogrpcservercontext.PrincipalOptions
+	}
+)
@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"context"
+
 	"google.golang.org/grpc"
 )
 
@@ -8,5 +10,18 @@ type (
 	// Authentication interface
 	Authentication interface {
 		Authenticate() grpc.UnaryServerInterceptor
+		AuthenticateStream() grpc.StreamServerInterceptor
+	}
+
+	// Authenticator interface for the authentications that can authenticate a request on their own. Unlike the
+	// interceptors, a method that is not intercepted fails with ErrMethodNotIntercepted instead of passing through
+	Authenticator interface {
+		AuthenticateCtx(ctx context.Context, fullMethod string) (context.Context, error)
+	}
+
+	// MethodInterceptor interface for the authentications that can tell which methods they intercept, so the
+	// wrappers of the authentications can skip the methods that are passed through
+	MethodInterceptor interface {
+		Intercepts(fullMethod string) bool
 	}
 )
@@ -0,0 +1,11 @@
+package jwt
+
+import (
+	"errors"
+)
+
+var (
+	ErrNilRevocationStore = errors.New("revocation store cannot be nil")
+	ErrTokenRevoked       = errors.New("token has been revoked")
+	ErrMissingExpiration  = errors.New("token claims have no expiration")
+)
@@ -3,6 +3,7 @@ package jwt
 import (
 	"context"
 
+	gojwt "github.com/ralvarezdev/go-jwt"
 	gojwtgrpc "github.com/ralvarezdev/go-jwt/grpc"
 	gojwttoken "github.com/ralvarezdev/go-jwt/token"
 	gojwtvalidator "github.com/ralvarezdev/go-jwt/token/validator"
@@ -12,13 +13,17 @@ import (
 
 	gogrpc "github.com/ralvarezdev/go-grpc"
 	gogrpcmd "github.com/ralvarezdev/go-grpc/metadata"
+	gogrpcservercontext "github.com/ralvarezdev/go-grpc/server/context"
+	gogrpcserverstream "github.com/ralvarezdev/go-grpc/server/stream"
 )
 
 type (
 	// Interceptor is the interceptor for the authentication
 	Interceptor struct {
-		validator     gojwtvalidator.Validator
-		interceptions map[string]*gojwttoken.Token
+		validator        gojwtvalidator.Validator
+		interceptions    *gogrpc.MethodMatcher[*gojwttoken.Token]
+		revocationStore  RevocationStore
+		principalOptions *gogrpcservercontext.PrincipalOptions
 	}
 )
 
@@ -28,6 +33,8 @@ type (
 //
 //   - validator: the JWT validator to validate the tokens
 //   - interceptions: the gRPC interceptions to determine which methods require authentication
+//   - revocationStore: the store to check if a token has been revoked (optional, can be nil)
+//   - principalOptions: the claims used to get the roles and the tenant of the principal (optional, can be nil)
 //
 // Returns:
 //
@@ -35,7 +42,9 @@ type (
 //   - error: if there was an error creating the interceptor
 func NewInterceptor(
 	validator gojwtvalidator.Validator,
-	interceptions map[string]*gojwttoken.Token,
+	interceptions *gogrpc.MethodMatcher[*gojwttoken.Token],
+	revocationStore RevocationStore,
+	principalOptions *gogrpcservercontext.PrincipalOptions,
 ) (*Interceptor, error) {
 	// Check if either the validator or the gRPC interceptions is nil
 	if validator == nil {
@@ -48,9 +57,115 @@ func NewInterceptor(
 	return &Interceptor{
 		validator,
 		interceptions,
+		revocationStore,
+		principalOptions,
 	}, nil
 }
 
+// authenticate validates the token of the given method and sets the raw token and token claims to the context
+//
+// Parameters:
+//
+//   - ctx: the context of the request
+//   - fullMethod: the full method name of the request
+//
+// Returns:
+//
+//   - context.Context: the context with the raw token, token claims and principal set, if the method is intercepted
+//   - error: a gRPC status error if the authentication failed
+func (i Interceptor) authenticate(
+	ctx context.Context,
+	fullMethod string,
+) (context.Context, error) {
+	// Check if the method should be intercepted
+	interception, ok := i.interceptions.Match(fullMethod)
+	if !ok || interception == nil {
+		return ctx, nil
+	}
+
+	// Get the raw token from the metadata
+	rawToken, err := gogrpcmd.GetIncomingCtxMetadataAuthorizationToken(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	// Validate the token and get the validated claims
+	claims, err := i.validator.ValidateClaims(ctx, rawToken, *interception)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	// Check if the token has been revoked
+	if i.revocationStore != nil {
+		revoked, revokedErr := i.revocationStore.IsRevoked(
+			ctx,
+			GetRevocationKey(rawToken, claims),
+		)
+		if revokedErr != nil {
+			return nil, status.Error(codes.Internal, gogrpc.InternalServerError)
+		}
+		if revoked {
+			return nil, status.Error(
+				codes.Unauthenticated,
+				ErrTokenRevoked.Error(),
+			)
+		}
+	}
+
+	// Set the raw token, token claims and principal to the context
+	subject, _ := claims[gojwt.SubjectClaim].(string)
+	ctx = gojwtgrpc.SetCtxToken(ctx, rawToken)
+	ctx = gojwtgrpc.SetCtxTokenClaims(ctx, claims)
+	ctx = gogrpcservercontext.SetCtxPrincipal(
+		ctx,
+		gogrpcservercontext.NewClaimsPrincipal(
+			gogrpcservercontext.AuthSchemeJWT,
+			subject,
+			claims,
+			i.principalOptions,
+		),
+	)
+	return ctx, nil
+}
+
+// Intercepts checks if the given method is intercepted
+//
+// Parameters:
+//
+//   - fullMethod: the full method name
+//
+// Returns:
+//
+//   - bool: true if the method is intercepted, false otherwise
+func (i Interceptor) Intercepts(fullMethod string) bool {
+	interception, ok := i.interceptions.Match(fullMethod)
+	return ok && interception != nil
+}
+
+// AuthenticateCtx authenticates a request of the given method without passing through the methods that are not
+// intercepted
+//
+// Parameters:
+//
+//   - ctx: the context of the request
+//   - fullMethod: the full method name of the request
+//
+// Returns:
+//
+//   - context.Context: the context with the raw token, token claims and principal set
+//   - error: gogrpc.ErrMethodNotIntercepted if the method is not intercepted, or a gRPC status error if the
+//     authentication failed
+func (i Interceptor) AuthenticateCtx(
+	ctx context.Context,
+	fullMethod string,
+) (context.Context, error) {
+	// Check if the method is intercepted
+	if !i.Intercepts(fullMethod) {
+		return nil, gogrpc.ErrMethodNotIntercepted
+	}
+	return i.authenticate(ctx, fullMethod)
+}
+
 // Authenticate returns the authentication interceptor
 //
 // Returns:
@@ -61,28 +176,35 @@ func (i Interceptor) Authenticate() grpc.UnaryServerInterceptor {
 		ctx context.Context, req any, info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (any, error) {
-		// Check if the method should be intercepted
-		interception, ok := i.interceptions[info.FullMethod]
-		if !ok || interception == nil {
-			return handler(ctx, req)
-		}
-
-		// Get the raw token from the metadata
-		rawToken, err := gogrpcmd.GetIncomingCtxMetadataAuthorizationToken(ctx)
+		// Authenticate the request
+		ctx, err := i.authenticate(ctx, info.FullMethod)
 		if err != nil {
-			return nil, status.Error(codes.Unauthenticated, err.Error())
+			return nil, err
 		}
+		return handler(ctx, req)
+	}
+}
 
-		// Validate the token and get the validated claims
-		claims, err := i.validator.ValidateClaims(ctx, rawToken, *interception)
+// AuthenticateStream returns the stream authentication interceptor
+//
+// Returns:
+//
+//   - grpc.StreamServerInterceptor: the stream authentication interceptor
+func (i Interceptor) AuthenticateStream() grpc.StreamServerInterceptor {
+	return func(
+		srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		// Authenticate the stream
+		ctx, err := i.authenticate(ss.Context(), info.FullMethod)
 		if err != nil {
-			return nil, status.Error(codes.Unauthenticated, err.Error())
+			return err
 		}
 
-		// Set the raw token and token claims to the context
-		ctx = gojwtgrpc.SetCtxToken(ctx, rawToken)
-		ctx = gojwtgrpc.SetCtxTokenClaims(ctx, claims)
-
-		return handler(ctx, req)
+		// Wrap the server stream with the authenticated context
+		return handler(
+			srv,
+			gogrpcserverstream.NewWrappedServerStream(ctx, ss),
+		)
 	}
 }
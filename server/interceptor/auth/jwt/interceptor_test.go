@@ -0,0 +1,118 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	gojwttoken "github.com/ralvarezdev/go-jwt/token"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	gogrpc "github.com/ralvarezdev/go-grpc"
+	"github.com/ralvarezdev/go-grpc/server/interceptor/auth/jwt/revocation"
+)
+
+type (
+	// stubValidator accepts the token "valid" and returns the given claims
+	stubValidator struct {
+		claims jwt.MapClaims
+	}
+)
+
+func (s stubValidator) GetToken(string) (*jwt.Token, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s stubValidator) GetClaims(string) (jwt.MapClaims, error) {
+	return s.claims, nil
+}
+
+func (s stubValidator) ValidateClaims(
+	_ context.Context,
+	rawToken string,
+	_ gojwttoken.Token,
+) (jwt.MapClaims, error) {
+	if rawToken != "valid" {
+		return nil, errors.New("invalid token")
+	}
+	return s.claims, nil
+}
+
+func TestAuthenticateRevokedToken(t *testing.T) {
+	claims := jwt.MapClaims{
+		"jti": "token-id",
+		"sub": "user",
+		"exp": float64(time.Now().Add(time.Hour).Unix()),
+	}
+	accessToken := gojwttoken.AccessToken
+	interceptions, err := gogrpc.NewMethodMatcher(
+		map[string]*gojwttoken.Token{
+			"/pkg.Service/*": &accessToken,
+		},
+	)
+	if err != nil {
+		t.Fatalf("NewMethodMatcher: %v", err)
+	}
+	store := revocation.NewMemoryStore()
+	interceptor, err := NewInterceptor(
+		stubValidator{claims: claims},
+		interceptions,
+		store,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("NewInterceptor: %v", err)
+	}
+
+	call := func() error {
+		ctx := metadata.NewIncomingContext(
+			context.Background(),
+			metadata.Pairs(gogrpc.AuthorizationMetadataKey, "Bearer valid"),
+		)
+		_, callErr := interceptor.Authenticate()(
+			ctx,
+			nil,
+			&grpc.UnaryServerInfo{FullMethod: "/pkg.Service/Method"},
+			func(context.Context, any) (any, error) {
+				return nil, nil
+			},
+		)
+		return callErr
+	}
+
+	if err = call(); err != nil {
+		t.Fatalf("expected the token to be accepted, got %v", err)
+	}
+	if err = RevokeToken(context.Background(), store, "valid", claims); err != nil {
+		t.Fatalf("RevokeToken: %v", err)
+	}
+	if err = call(); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated for a revoked token, got %v", err)
+	}
+}
+
+func TestGetRevocationKey(t *testing.T) {
+	if key := GetRevocationKey("raw", map[string]any{"jti": "id"}); key != "id" {
+		t.Fatalf("expected the JWT ID, got %q", key)
+	}
+	if key := GetRevocationKey("raw", nil); key != HashToken("raw") {
+		t.Fatalf("expected the token hash, got %q", key)
+	}
+}
+
+func TestRevokeTokenMissingExpiration(t *testing.T) {
+	err := RevokeToken(
+		context.Background(),
+		revocation.NewMemoryStore(),
+		"raw",
+		map[string]any{},
+	)
+	if !errors.Is(err, ErrMissingExpiration) {
+		t.Fatalf("expected ErrMissingExpiration, got %v", err)
+	}
+}
@@ -0,0 +1,16 @@
+package jwt
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// RevocationStore is the interface for the stores of revoked tokens
+	//
+	// The key is either the JWT ID claim of the token or, if the token has no JWT ID, the token hash
+	RevocationStore interface {
+		Revoke(ctx context.Context, key string, expiresAt time.Time) error
+		IsRevoked(ctx context.Context, key string) (bool, error)
+	}
+)
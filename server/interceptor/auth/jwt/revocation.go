@@ -0,0 +1,85 @@
+package jwt
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	gojwt "github.com/ralvarezdev/go-jwt"
+)
+
+// HashToken returns the hex-encoded SHA-256 hash of the raw token
+//
+// Parameters:
+//
+//   - rawToken: the raw token to hash
+//
+// Returns:
+//
+//   - string: the token hash
+func HashToken(rawToken string) string {
+	hash := sha256.Sum256([]byte(rawToken))
+	return hex.EncodeToString(hash[:])
+}
+
+// GetRevocationKey returns the key used to check if a token is revoked
+//
+// Parameters:
+//
+//   - rawToken: the raw token
+//   - claims: the token claims
+//
+// Returns:
+//
+//   - string: the JWT ID claim if set, otherwise the token hash
+func GetRevocationKey(rawToken string, claims map[string]any) string {
+	if jwtID, ok := claims[gojwt.IDClaim].(string); ok && jwtID != "" {
+		return jwtID
+	}
+	return HashToken(rawToken)
+}
+
+// RevokeToken revokes a token until it expires
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - store: the revocation store
+//   - rawToken: the raw token to revoke
+//   - claims: the token claims
+//
+// Returns:
+//
+//   - error: if the store is nil, the claims have no expiration or the token could not be revoked
+func RevokeToken(
+	ctx context.Context,
+	store RevocationStore,
+	rawToken string,
+	claims map[string]any,
+) error {
+	// Check if the store is nil
+	if store == nil {
+		return ErrNilRevocationStore
+	}
+
+	// Get the expiration of the token
+	var expiresAt time.Time
+	switch exp := claims["exp"].(type) {
+	case float64:
+		expiresAt = time.Unix(int64(exp), 0)
+	case int64:
+		expiresAt = time.Unix(exp, 0)
+	case json.Number:
+		seconds, err := exp.Int64()
+		if err != nil {
+			return ErrMissingExpiration
+		}
+		expiresAt = time.Unix(seconds, 0)
+	default:
+		return ErrMissingExpiration
+	}
+
+	return store.Revoke(ctx, GetRevocationKey(rawToken, claims), expiresAt)
+}
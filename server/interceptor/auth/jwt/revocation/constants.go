@@ -0,0 +1,7 @@
+package revocation
+
+const (
+	// MinSweepThreshold is the minimum number of entries of the in-memory store before a revocation sweeps its
+	// expired entries
+	MinSweepThreshold = 64
+)
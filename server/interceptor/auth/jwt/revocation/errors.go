@@ -0,0 +1,10 @@
+package revocation
+
+import (
+	"errors"
+)
+
+var (
+	ErrEmptyKey          = errors.New("revocation key cannot be empty")
+	ErrEmptySnapshotPath = errors.New("snapshot path cannot be empty")
+)
@@ -0,0 +1,154 @@
+package revocation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type (
+	// FileStore is an in-memory revocation store that persists its entries to a snapshot file, so the revocations
+	// survive a restart
+	FileStore struct {
+		*MemoryStore
+		path   string
+		mutex  sync.Mutex
+		logger *slog.Logger
+	}
+)
+
+// NewFileStore creates a new file-backed revocation store, loading the snapshot file if it exists
+//
+// Parameters:
+//
+//   - path: the path of the snapshot file
+//   - logger: the logger to use (optional, can be nil)
+//
+// Returns:
+//
+//   - *FileStore: the file-backed revocation store
+//   - error: if the path is empty or the snapshot file could not be loaded
+func NewFileStore(path string, logger *slog.Logger) (*FileStore, error) {
+	// Check if the path is empty
+	if path == "" {
+		return nil, ErrEmptySnapshotPath
+	}
+
+	if logger != nil {
+		logger = logger.With(
+			slog.String("store", "jwt_revocation_file"),
+		)
+	}
+
+	f := &FileStore{
+		MemoryStore: NewMemoryStore(),
+		path:        path,
+		logger:      logger,
+	}
+	if err := f.load(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// load loads the snapshot file into the store, ignoring expired entries
+//
+// Returns:
+//
+//   - error: if the snapshot file could not be read or parsed
+func (f *FileStore) load() error {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	// Parse the snapshot
+	var entries map[string]time.Time
+	if err = json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	f.MemoryStore.mutex.Lock()
+	defer f.MemoryStore.mutex.Unlock()
+	for key, expiresAt := range entries {
+		if expiresAt.After(now) {
+			f.MemoryStore.entries[key] = expiresAt
+		}
+	}
+	return nil
+}
+
+// Revoke revokes the given key until the given expiration time and writes the snapshot file
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - key: the key to revoke
+//   - expiresAt: the time at which the revoked token expires
+//
+// Returns:
+//
+//   - error: if the key is empty or the snapshot file could not be written
+func (f *FileStore) Revoke(
+	ctx context.Context,
+	key string,
+	expiresAt time.Time,
+) error {
+	if err := f.MemoryStore.Revoke(ctx, key, expiresAt); err != nil {
+		return err
+	}
+	return f.Snapshot()
+}
+
+// Snapshot writes the non-expired entries of the store to the snapshot file. The entries are read while holding the
+// snapshot lock, so a snapshot never overwrites a newer one
+//
+// Returns:
+//
+//   - error: if the snapshot file could not be written
+func (f *FileStore) Snapshot() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	data, err := json.Marshal(f.Entries())
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file and rename it, so the snapshot is never left half-written
+	tmpFile, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmpFile.Name()
+	if _, err = tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err = tmpFile.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err = os.Rename(tmpPath, f.path); err != nil {
+		_ = os.Remove(tmpPath)
+		if f.logger != nil {
+			f.logger.Error(
+				"Failed to write revocation snapshot file",
+				slog.String("file_path", f.path),
+				slog.String("error", err.Error()),
+			)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package revocation
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type (
+	// MemoryStore is an in-memory revocation store whose entries expire when the revoked token would have expired.
+	// The expired entries are swept by the revocations once the store has doubled its size since the last sweep, so
+	// the store does not grow without bound in long-running servers
+	MemoryStore struct {
+		mutex          sync.RWMutex
+		entries        map[string]time.Time
+		sweepThreshold int
+	}
+)
+
+// NewMemoryStore creates a new in-memory revocation store
+//
+// Returns:
+//
+//   - *MemoryStore: the in-memory revocation store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries:        make(map[string]time.Time),
+		sweepThreshold: MinSweepThreshold,
+	}
+}
+
+// Revoke revokes the given key until the given expiration time
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - key: the key to revoke
+//   - expiresAt: the time at which the revoked token expires
+//
+// Returns:
+//
+//   - error: if the key is empty
+func (m *MemoryStore) Revoke(
+	ctx context.Context,
+	key string,
+	expiresAt time.Time,
+) error {
+	// Check if the key is empty
+	if key == "" {
+		return ErrEmptyKey
+	}
+
+	// Skip tokens that have already expired
+	now := time.Now()
+	if !expiresAt.After(now) {
+		return nil
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	// Sweep the expired entries once the store has doubled its size since the last sweep
+	if len(m.entries) >= m.sweepThreshold {
+		m.deleteExpired(now)
+		m.sweepThreshold = max(2*len(m.entries), MinSweepThreshold)
+	}
+	m.entries[key] = expiresAt
+	return nil
+}
+
+// IsRevoked checks if the given key is revoked
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - key: the key to check
+//
+// Returns:
+//
+//   - bool: true if the key is revoked and has not expired, false otherwise
+//   - error: always nil
+func (m *MemoryStore) IsRevoked(ctx context.Context, key string) (bool, error) {
+	m.mutex.RLock()
+	expiresAt, ok := m.entries[key]
+	m.mutex.RUnlock()
+	if !ok {
+		return false, nil
+	}
+
+	// Remove the entry if it has expired
+	if !expiresAt.After(time.Now()) {
+		m.mutex.Lock()
+		if current, found := m.entries[key]; found && current.Equal(expiresAt) {
+			delete(m.entries, key)
+		}
+		m.mutex.Unlock()
+		return false, nil
+	}
+	return true, nil
+}
+
+// deleteExpired deletes the expired entries of the store, the caller must hold the lock
+//
+// Parameters:
+//
+//   - now: the current time
+func (m *MemoryStore) deleteExpired(now time.Time) {
+	for key, expiresAt := range m.entries {
+		if !expiresAt.After(now) {
+			delete(m.entries, key)
+		}
+	}
+}
+
+// DeleteExpired deletes the expired entries of the store
+func (m *MemoryStore) DeleteExpired() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.deleteExpired(time.Now())
+}
+
+// Entries returns a copy of the non-expired entries of the store
+//
+// Returns:
+//
+//   - map[string]time.Time: the revoked keys and their expiration times
+func (m *MemoryStore) Entries() map[string]time.Time {
+	now := time.Now()
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	entries := make(map[string]time.Time, len(m.entries))
+	for key, expiresAt := range m.entries {
+		if expiresAt.After(now) {
+			entries[key] = expiresAt
+		}
+	}
+	return entries
+}
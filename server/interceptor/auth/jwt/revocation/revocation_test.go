@@ -0,0 +1,148 @@
+package revocation
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	if err := store.Revoke(ctx, "", time.Now().Add(time.Hour)); err != ErrEmptyKey {
+		t.Fatalf("expected ErrEmptyKey, got %v", err)
+	}
+	if err := store.Revoke(ctx, "active", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if err := store.Revoke(ctx, "expired", time.Now().Add(-time.Hour)); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+
+	for key, want := range map[string]bool{
+		"active":  true,
+		"expired": false,
+		"unknown": false,
+	} {
+		revoked, err := store.IsRevoked(ctx, key)
+		if err != nil {
+			t.Fatalf("IsRevoked(%q): %v", key, err)
+		}
+		if revoked != want {
+			t.Fatalf("IsRevoked(%q) = %v, want %v", key, revoked, want)
+		}
+	}
+}
+
+func TestMemoryStoreExpiration(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	if err := store.Revoke(ctx, "key", time.Now().Add(20*time.Millisecond)); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	time.Sleep(40 * time.Millisecond)
+
+	store.DeleteExpired()
+	if entries := store.Entries(); len(entries) != 0 {
+		t.Fatalf("expected no entries after expiration, got %v", entries)
+	}
+	if revoked, _ := store.IsRevoked(ctx, "key"); revoked {
+		t.Fatal("expected the expired key not to be revoked")
+	}
+}
+
+func TestMemoryStoreRevokeSweepsExpired(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	for n := range MinSweepThreshold {
+		if err := store.Revoke(ctx, fmt.Sprintf("key-%d", n), time.Now().Add(20*time.Millisecond)); err != nil {
+			t.Fatalf("Revoke: %v", err)
+		}
+	}
+	time.Sleep(40 * time.Millisecond)
+
+	// The next revocation sweeps the expired entries without an explicit DeleteExpired call
+	if err := store.Revoke(ctx, "active", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	store.mutex.RLock()
+	length := len(store.entries)
+	store.mutex.RUnlock()
+	if length != 1 {
+		t.Fatalf("expected the expired entries to be swept, got %d entries", length)
+	}
+	if revoked, _ := store.IsRevoked(ctx, "active"); !revoked {
+		t.Fatal("expected the active key to be revoked")
+	}
+}
+
+func TestFileStoreSurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "revocations.json")
+
+	store, err := NewFileStore(path, nil)
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	if err = store.Revoke(ctx, "key", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+
+	reloaded, err := NewFileStore(path, nil)
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	revoked, err := reloaded.IsRevoked(ctx, "key")
+	if err != nil {
+		t.Fatalf("IsRevoked: %v", err)
+	}
+	if !revoked {
+		t.Fatal("expected the key to be revoked after a restart")
+	}
+}
+
+func TestFileStoreConcurrentRevocations(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "revocations.json")
+
+	store, err := NewFileStore(path, nil)
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+
+	const revocations = 50
+	var wg sync.WaitGroup
+	for n := range revocations {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if revokeErr := store.Revoke(
+				ctx,
+				fmt.Sprintf("key-%d", n),
+				time.Now().Add(time.Hour),
+			); revokeErr != nil {
+				t.Errorf("Revoke: %v", revokeErr)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Every revocation must be in the last snapshot
+	reloaded, err := NewFileStore(path, nil)
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	if entries := reloaded.Entries(); len(entries) != revocations {
+		t.Fatalf("expected %d revocations after a restart, got %d", revocations, len(entries))
+	}
+}
+
+func TestNewFileStoreEmptyPath(t *testing.T) {
+	if _, err := NewFileStore("", nil); err != ErrEmptySnapshotPath {
+		t.Fatalf("expected ErrEmptySnapshotPath, got %v", err)
+	}
+}
@@ -0,0 +1,28 @@
+package loader
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNilOption              = errors.New("method option extension type cannot be nil")
+	ErrInvalidOptionExtendee  = errors.New("option must extend google.protobuf.MethodOptions")
+	ErrUnsupportedOptionKind  = errors.New("unsupported method option kind")
+	ErrEmptyDescriptorSetPath = errors.New("descriptor set path cannot be empty")
+	ErrUnsupportedTokenType   = errors.New("unsupported token type")
+)
+
+// NewUnsupportedTokenTypeError creates a new unsupported token type error
+//
+// Parameters:
+//
+//   - fullMethod: the full method name that declares the token type
+//   - tokenType: the unsupported token type
+//
+// Returns:
+//
+//   - error: the unsupported token type error
+func NewUnsupportedTokenTypeError(fullMethod, tokenType string) error {
+	return fmt.Errorf("%w: %q on %s", ErrUnsupportedTokenType, tokenType, fullMethod)
+}
@@ -0,0 +1,336 @@
+package loader
+
+import (
+	"log/slog"
+	"os"
+	"strings"
+	"unicode"
+
+	gojwttoken "github.com/ralvarezdev/go-jwt/token"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	gogrpc "github.com/ralvarezdev/go-grpc"
+)
+
+type (
+	// Loader builds the interceptions of the authentication interceptors from custom protobuf method options
+	Loader struct {
+		files  *protoregistry.Files
+		logger *slog.Logger
+	}
+)
+
+// NewLoader creates a new method options loader
+//
+// Parameters:
+//
+//   - files: the file registry to walk (optional, if nil protoregistry.GlobalFiles is used)
+//   - logger: the logger to use (optional, can be nil)
+//
+// Returns:
+//
+//   - *Loader: the loader
+func NewLoader(files *protoregistry.Files, logger *slog.Logger) *Loader {
+	if files == nil {
+		files = protoregistry.GlobalFiles
+	}
+
+	if logger != nil {
+		logger = logger.With(
+			slog.String("loader", "grpc_method_options"),
+		)
+	}
+
+	return &Loader{
+		files:  files,
+		logger: logger,
+	}
+}
+
+// NewLoaderFromDescriptorSetFile creates a new method options loader from a descriptor set file
+//
+// The descriptor set must include all the imports, e.g. generated with protoc --include_imports
+//
+// Parameters:
+//
+//   - path: the path of the descriptor set file
+//   - logger: the logger to use (optional, can be nil)
+//
+// Returns:
+//
+//   - *Loader: the loader
+//   - error: if the descriptor set file could not be read or parsed
+func NewLoaderFromDescriptorSetFile(path string, logger *slog.Logger) (
+	*Loader,
+	error,
+) {
+	// Check if the path is empty
+	if path == "" {
+		return nil, ErrEmptyDescriptorSetPath
+	}
+
+	// Read the descriptor set file
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the descriptor set
+	var set descriptorpb.FileDescriptorSet
+	if err = proto.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+	files, err := protodesc.NewFiles(&set)
+	if err != nil {
+		return nil, err
+	}
+	return NewLoader(files, logger), nil
+}
+
+// LoadMethodOptions walks the registered services and gets the value of the given option for each method that sets it
+//
+// Parameters:
+//
+//   - option: the method option extension type
+//
+// Returns:
+//
+//   - map[string]protoreflect.Value: the option values keyed by the full method name
+//   - error: if the option is nil, does not extend the method options or could not be read
+func (l Loader) LoadMethodOptions(option protoreflect.ExtensionType) (
+	map[string]protoreflect.Value,
+	error,
+) {
+	// Check if the option is nil
+	if option == nil {
+		return nil, ErrNilOption
+	}
+
+	// Check if the option extends the method options
+	methodOptionsName := (&descriptorpb.MethodOptions{}).ProtoReflect().Descriptor().FullName()
+	if option.TypeDescriptor().ContainingMessage().FullName() != methodOptionsName {
+		return nil, ErrInvalidOptionExtendee
+	}
+
+	// Create a resolver with the option, so it is also resolved from the options parsed as unknown fields
+	resolver := new(protoregistry.Types)
+	if err := resolver.RegisterExtension(option); err != nil {
+		return nil, err
+	}
+	unmarshalOptions := proto.UnmarshalOptions{Resolver: resolver}
+
+	values := make(map[string]protoreflect.Value)
+	var rangeErr error
+	l.files.RangeFiles(
+		func(file protoreflect.FileDescriptor) bool {
+			services := file.Services()
+			for i := 0; i < services.Len(); i++ {
+				if rangeErr = l.loadServiceMethodOptions(
+					services.Get(i),
+					option,
+					unmarshalOptions,
+					values,
+				); rangeErr != nil {
+					return false
+				}
+			}
+			return true
+		},
+	)
+	if rangeErr != nil {
+		return nil, rangeErr
+	}
+	return values, nil
+}
+
+// loadServiceMethodOptions gets the value of the given option for each method of the service that sets it
+//
+// Parameters:
+//
+//   - service: the service descriptor
+//   - option: the method option extension type
+//   - unmarshalOptions: the unmarshal options used to resolve the option
+//   - values: the option values keyed by the full method name, where the found values are added
+//
+// Returns:
+//
+//   - error: if the method options could not be read
+func (l Loader) loadServiceMethodOptions(
+	service protoreflect.ServiceDescriptor,
+	option protoreflect.ExtensionType,
+	unmarshalOptions proto.UnmarshalOptions,
+	values map[string]protoreflect.Value,
+) error {
+	optionDesc := option.TypeDescriptor()
+	methods := service.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		method := methods.Get(i)
+
+		// Get the method options, resolving the option from the unknown fields if needed
+		methodOptions := method.Options()
+		if methodOptions == nil {
+			continue
+		}
+		data, err := proto.Marshal(methodOptions)
+		if err != nil {
+			return err
+		}
+		resolvedOptions := &descriptorpb.MethodOptions{}
+		if err = unmarshalOptions.Unmarshal(data, resolvedOptions); err != nil {
+			return err
+		}
+
+		// Check if the option is set
+		if !proto.HasExtension(resolvedOptions, option) {
+			continue
+		}
+		fullMethod := "/" + string(service.FullName()) + "/" + string(method.Name())
+		values[fullMethod] = resolvedOptions.ProtoReflect().Get(optionDesc)
+
+		if l.logger != nil {
+			l.logger.Debug(
+				"Found method option",
+				slog.String("method", fullMethod),
+				slog.String("option", string(optionDesc.FullName())),
+			)
+		}
+	}
+	return nil
+}
+
+// getEnumValuePrefix gets the prefix of the enum value names of the buf lint style enums, e.g. "TOKEN_TYPE_" for the
+// TokenType enum
+//
+// Parameters:
+//
+//   - enum: the enum descriptor
+//
+// Returns:
+//
+//   - string: the upper snake case prefix of the enum value names
+func getEnumValuePrefix(enum protoreflect.EnumDescriptor) string {
+	name := []rune(string(enum.Name()))
+	var prefix strings.Builder
+	for index, r := range name {
+		// Separate the words on the lower to upper case changes, and before the last upper case letter of an acronym
+		if index > 0 && unicode.IsUpper(r) {
+			previous := name[index-1]
+			nextIsLower := index+1 < len(name) && unicode.IsLower(name[index+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower) {
+				prefix.WriteRune('_')
+			}
+		}
+		prefix.WriteRune(unicode.ToUpper(r))
+	}
+	prefix.WriteRune('_')
+	return prefix.String()
+}
+
+// getEnumTokenType gets the token type of an enum value, stripping the enum prefix of the buf lint style enums
+//
+// Parameters:
+//
+//   - enumValue: the enum value descriptor
+//
+// Returns:
+//
+//   - string: the token type in lower case
+func getEnumTokenType(enumValue protoreflect.EnumValueDescriptor) string {
+	name := string(enumValue.Name())
+	name = strings.TrimPrefix(name, getEnumValuePrefix(enumValue.Parent().(protoreflect.EnumDescriptor)))
+	return strings.ToLower(name)
+}
+
+// LoadJWTInterceptions builds the JWT interceptions from a method option declaring the token type
+//
+// The option can be a string with the token type, e.g. "access_token", or an enum whose value names are the token
+// types in upper case, e.g. ACCESS_TOKEN, optionally prefixed by the enum name as in the buf lint style enums, e.g.
+// TOKEN_TYPE_ACCESS_TOKEN for the TokenType enum. The token types must be either gojwttoken.AccessToken or
+// gojwttoken.RefreshToken
+//
+// Parameters:
+//
+//   - tokenOption: the method option extension type
+//
+// Returns:
+//
+//   - *gogrpc.MethodMatcher[*gojwttoken.Token]: the JWT interceptions
+//   - error: if the option could not be read, is of an unsupported kind or declares an unsupported token type
+func (l Loader) LoadJWTInterceptions(tokenOption protoreflect.ExtensionType) (
+	*gogrpc.MethodMatcher[*gojwttoken.Token],
+	error,
+) {
+	values, err := l.LoadMethodOptions(tokenOption)
+	if err != nil {
+		return nil, err
+	}
+
+	interceptions := make(map[string]*gojwttoken.Token)
+	optionDesc := tokenOption.TypeDescriptor()
+	for fullMethod, value := range values {
+		var tokenType string
+		switch optionDesc.Kind() {
+		case protoreflect.StringKind:
+			tokenType = value.String()
+		case protoreflect.EnumKind:
+			enumValue := optionDesc.Enum().Values().ByNumber(value.Enum())
+			if enumValue == nil || value.Enum() == 0 {
+				continue
+			}
+			tokenType = getEnumTokenType(enumValue)
+		default:
+			return nil, ErrUnsupportedOptionKind
+		}
+
+		// Skip methods that do not require a token
+		if tokenType == "" {
+			continue
+		}
+
+		// Check if the token type is supported
+		token := gojwttoken.Token(tokenType)
+		if token != gojwttoken.AccessToken && token != gojwttoken.RefreshToken {
+			return nil, NewUnsupportedTokenTypeError(fullMethod, tokenType)
+		}
+		interceptions[fullMethod] = &token
+	}
+	return gogrpc.NewMethodMatcher(interceptions)
+}
+
+// LoadAPIKeyInterceptions builds the API key interceptions from a boolean method option declaring if an API key is
+// required
+//
+// Parameters:
+//
+//   - apiKeyOption: the method option extension type
+//
+// Returns:
+//
+//   - *gogrpc.MethodMatcher[struct{}]: the API key interceptions
+//   - error: if the option could not be read or is of an unsupported kind
+func (l Loader) LoadAPIKeyInterceptions(apiKeyOption protoreflect.ExtensionType) (
+	*gogrpc.MethodMatcher[struct{}],
+	error,
+) {
+	values, err := l.LoadMethodOptions(apiKeyOption)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if the option is a boolean
+	if apiKeyOption.TypeDescriptor().Kind() != protoreflect.BoolKind {
+		return nil, ErrUnsupportedOptionKind
+	}
+
+	methods := make([]string, 0, len(values))
+	for fullMethod, value := range values {
+		if value.Bool() {
+			methods = append(methods, fullMethod)
+		}
+	}
+	return gogrpc.NewMethodSetMatcher(methods)
+}
@@ -0,0 +1,373 @@
+package loader
+
+import (
+	"errors"
+	"testing"
+
+	gojwttoken "github.com/ralvarezdev/go-jwt/token"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const (
+	testOptionsFile = "test/loader/options.proto"
+	testService     = "test.loader.Service"
+)
+
+type (
+	// testOptions are the method option extension types of the test options file
+	testOptions struct {
+		file        protoreflect.FileDescriptor
+		token       protoreflect.ExtensionType
+		legacyToken protoreflect.ExtensionType
+		tokenName   protoreflect.ExtensionType
+		apiKey      protoreflect.ExtensionType
+		fieldOption protoreflect.ExtensionType
+	}
+)
+
+// newTestEnum creates an enum descriptor with the given value names, numbered from zero
+func newTestEnum(name string, values ...string) *descriptorpb.EnumDescriptorProto {
+	enum := &descriptorpb.EnumDescriptorProto{Name: proto.String(name)}
+	for number, value := range values {
+		enum.Value = append(
+			enum.Value, &descriptorpb.EnumValueDescriptorProto{
+				Name:   proto.String(value),
+				Number: proto.Int32(int32(number)),
+			},
+		)
+	}
+	return enum
+}
+
+// newTestExtension creates an extension descriptor of the given extendee
+func newTestExtension(
+	name string,
+	number int32,
+	fieldType descriptorpb.FieldDescriptorProto_Type,
+	typeName string,
+	extendee string,
+) *descriptorpb.FieldDescriptorProto {
+	extension := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		Number:   proto.Int32(number),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     fieldType.Enum(),
+		Extendee: proto.String(extendee),
+	}
+	if typeName != "" {
+		extension.TypeName = proto.String(typeName)
+	}
+	return extension
+}
+
+// newTestOptions creates the test options file, with a buf lint style token type enum, a legacy token type enum and
+// string and boolean method options
+func newTestOptions(t *testing.T) *testOptions {
+	t.Helper()
+
+	file, err := protodesc.NewFile(
+		&descriptorpb.FileDescriptorProto{
+			Name:       proto.String(testOptionsFile),
+			Package:    proto.String("test.loader"),
+			Syntax:     proto.String("proto3"),
+			Dependency: []string{"google/protobuf/descriptor.proto"},
+			EnumType: []*descriptorpb.EnumDescriptorProto{
+				newTestEnum(
+					"TokenType",
+					"TOKEN_TYPE_UNSPECIFIED",
+					"TOKEN_TYPE_ACCESS_TOKEN",
+					"TOKEN_TYPE_REFRESH_TOKEN",
+					"TOKEN_TYPE_ID_TOKEN",
+				),
+				newTestEnum("LegacyToken", "NONE", "ACCESS_TOKEN", "REFRESH_TOKEN"),
+			},
+			Extension: []*descriptorpb.FieldDescriptorProto{
+				newTestExtension(
+					"token",
+					50001,
+					descriptorpb.FieldDescriptorProto_TYPE_ENUM,
+					".test.loader.TokenType",
+					".google.protobuf.MethodOptions",
+				),
+				newTestExtension(
+					"legacy_token",
+					50002,
+					descriptorpb.FieldDescriptorProto_TYPE_ENUM,
+					".test.loader.LegacyToken",
+					".google.protobuf.MethodOptions",
+				),
+				newTestExtension(
+					"token_name",
+					50003,
+					descriptorpb.FieldDescriptorProto_TYPE_STRING,
+					"",
+					".google.protobuf.MethodOptions",
+				),
+				newTestExtension(
+					"api_key",
+					50004,
+					descriptorpb.FieldDescriptorProto_TYPE_BOOL,
+					"",
+					".google.protobuf.MethodOptions",
+				),
+				newTestExtension(
+					"field_option",
+					50005,
+					descriptorpb.FieldDescriptorProto_TYPE_BOOL,
+					"",
+					".google.protobuf.FieldOptions",
+				),
+			},
+		},
+		protoregistry.GlobalFiles,
+	)
+	if err != nil {
+		t.Fatalf("NewFile: %v", err)
+	}
+
+	extensions := file.Extensions()
+	return &testOptions{
+		file:        file,
+		token:       dynamicpb.NewExtensionType(extensions.ByName("token")),
+		legacyToken: dynamicpb.NewExtensionType(extensions.ByName("legacy_token")),
+		tokenName:   dynamicpb.NewExtensionType(extensions.ByName("token_name")),
+		apiKey:      dynamicpb.NewExtensionType(extensions.ByName("api_key")),
+		fieldOption: dynamicpb.NewExtensionType(extensions.ByName("field_option")),
+	}
+}
+
+// newTestLoader creates a loader of a service with the given methods and their options
+func newTestLoader(
+	t *testing.T,
+	options *testOptions,
+	methods map[string]*descriptorpb.MethodOptions,
+) *Loader {
+	t.Helper()
+
+	service := &descriptorpb.ServiceDescriptorProto{Name: proto.String("Service")}
+	for name, methodOptions := range methods {
+		service.Method = append(
+			service.Method, &descriptorpb.MethodDescriptorProto{
+				Name:       proto.String(name),
+				InputType:  proto.String(".test.loader.Empty"),
+				OutputType: proto.String(".test.loader.Empty"),
+				Options:    methodOptions,
+			},
+		)
+	}
+
+	files := new(protoregistry.Files)
+	if err := files.RegisterFile(options.file); err != nil {
+		t.Fatalf("RegisterFile: %v", err)
+	}
+	file, err := protodesc.NewFile(
+		&descriptorpb.FileDescriptorProto{
+			Name:        proto.String("test/loader/service.proto"),
+			Package:     proto.String("test.loader"),
+			Syntax:      proto.String("proto3"),
+			Dependency:  []string{testOptionsFile},
+			MessageType: []*descriptorpb.DescriptorProto{{Name: proto.String("Empty")}},
+			Service:     []*descriptorpb.ServiceDescriptorProto{service},
+		},
+		files,
+	)
+	if err != nil {
+		t.Fatalf("NewFile: %v", err)
+	}
+	if err = files.RegisterFile(file); err != nil {
+		t.Fatalf("RegisterFile: %v", err)
+	}
+	return NewLoader(files, nil)
+}
+
+// newMethodOptions creates method options with the given extension set
+func newMethodOptions(extension protoreflect.ExtensionType, value any) *descriptorpb.MethodOptions {
+	methodOptions := &descriptorpb.MethodOptions{}
+	proto.SetExtension(methodOptions, extension, value)
+	return methodOptions
+}
+
+func TestLoadJWTInterceptions(t *testing.T) {
+	options := newTestOptions(t)
+	tests := []struct {
+		name     string
+		option   protoreflect.ExtensionType
+		methods  map[string]*descriptorpb.MethodOptions
+		expected map[string]gojwttoken.Token
+	}{
+		{
+			name:   "buf lint style enum",
+			option: options.token,
+			methods: map[string]*descriptorpb.MethodOptions{
+				"Access":      newMethodOptions(options.token, protoreflect.EnumNumber(1)),
+				"Refresh":     newMethodOptions(options.token, protoreflect.EnumNumber(2)),
+				"Unspecified": newMethodOptions(options.token, protoreflect.EnumNumber(0)),
+				"Public":      nil,
+			},
+			expected: map[string]gojwttoken.Token{
+				"Access":  gojwttoken.AccessToken,
+				"Refresh": gojwttoken.RefreshToken,
+			},
+		},
+		{
+			name:   "enum without prefix",
+			option: options.legacyToken,
+			methods: map[string]*descriptorpb.MethodOptions{
+				"Access":  newMethodOptions(options.legacyToken, protoreflect.EnumNumber(1)),
+				"Refresh": newMethodOptions(options.legacyToken, protoreflect.EnumNumber(2)),
+			},
+			expected: map[string]gojwttoken.Token{
+				"Access":  gojwttoken.AccessToken,
+				"Refresh": gojwttoken.RefreshToken,
+			},
+		},
+		{
+			name:   "string",
+			option: options.tokenName,
+			methods: map[string]*descriptorpb.MethodOptions{
+				"Access": newMethodOptions(options.tokenName, "access_token"),
+				"Public": newMethodOptions(options.tokenName, ""),
+			},
+			expected: map[string]gojwttoken.Token{
+				"Access": gojwttoken.AccessToken,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				loader := newTestLoader(t, options, test.methods)
+				interceptions, err := loader.LoadJWTInterceptions(test.option)
+				if err != nil {
+					t.Fatalf("LoadJWTInterceptions: %v", err)
+				}
+				for name := range test.methods {
+					token, matched := interceptions.Match("/" + testService + "/" + name)
+					expected, intercepted := test.expected[name]
+					if matched != intercepted || (matched && *token != expected) {
+						t.Fatalf("%s: expected (%q, %v), got (%v, %v)", name, expected, intercepted, token, matched)
+					}
+				}
+			},
+		)
+	}
+}
+
+func TestLoadJWTInterceptionsErrors(t *testing.T) {
+	options := newTestOptions(t)
+	tests := []struct {
+		name     string
+		option   protoreflect.ExtensionType
+		methods  map[string]*descriptorpb.MethodOptions
+		expected error
+	}{
+		{
+			name:   "unsupported enum token type",
+			option: options.token,
+			methods: map[string]*descriptorpb.MethodOptions{
+				"ID": newMethodOptions(options.token, protoreflect.EnumNumber(3)),
+			},
+			expected: ErrUnsupportedTokenType,
+		},
+		{
+			name:   "unsupported string token type",
+			option: options.tokenName,
+			methods: map[string]*descriptorpb.MethodOptions{
+				"ID": newMethodOptions(options.tokenName, "id_token"),
+			},
+			expected: ErrUnsupportedTokenType,
+		},
+		{
+			name:   "unsupported option kind",
+			option: options.apiKey,
+			methods: map[string]*descriptorpb.MethodOptions{
+				"Method": newMethodOptions(options.apiKey, true),
+			},
+			expected: ErrUnsupportedOptionKind,
+		},
+		{
+			name:     "nil option",
+			expected: ErrNilOption,
+		},
+		{
+			name:     "option not extending the method options",
+			option:   options.fieldOption,
+			expected: ErrInvalidOptionExtendee,
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				loader := newTestLoader(t, options, test.methods)
+				if _, err := loader.LoadJWTInterceptions(test.option); !errors.Is(err, test.expected) {
+					t.Fatalf("expected %v, got %v", test.expected, err)
+				}
+			},
+		)
+	}
+}
+
+func TestLoadAPIKeyInterceptions(t *testing.T) {
+	options := newTestOptions(t)
+	loader := newTestLoader(
+		t,
+		options,
+		map[string]*descriptorpb.MethodOptions{
+			"Required":    newMethodOptions(options.apiKey, true),
+			"NotRequired": newMethodOptions(options.apiKey, false),
+			"Public":      nil,
+		},
+	)
+
+	interceptions, err := loader.LoadAPIKeyInterceptions(options.apiKey)
+	if err != nil {
+		t.Fatalf("LoadAPIKeyInterceptions: %v", err)
+	}
+	for name, expected := range map[string]bool{
+		"Required":    true,
+		"NotRequired": false,
+		"Public":      false,
+	} {
+		if _, matched := interceptions.Match("/" + testService + "/" + name); matched != expected {
+			t.Fatalf("%s: expected %v, got %v", name, expected, matched)
+		}
+	}
+
+	if _, err = loader.LoadAPIKeyInterceptions(options.tokenName); !errors.Is(err, ErrUnsupportedOptionKind) {
+		t.Fatalf("expected ErrUnsupportedOptionKind, got %v", err)
+	}
+}
+
+func TestGetEnumValuePrefix(t *testing.T) {
+	for name, expected := range map[string]string{
+		"TokenType":  "TOKEN_TYPE_",
+		"JWTType":    "JWT_TYPE_",
+		"Token2Type": "TOKEN2_TYPE_",
+		"Token":      "TOKEN_",
+	} {
+		t.Run(
+			name, func(t *testing.T) {
+				file, err := protodesc.NewFile(
+					&descriptorpb.FileDescriptorProto{
+						Name:     proto.String("test/loader/enum.proto"),
+						Package:  proto.String("test.loader"),
+						Syntax:   proto.String("proto3"),
+						EnumType: []*descriptorpb.EnumDescriptorProto{newTestEnum(name, "UNSPECIFIED")},
+					},
+					nil,
+				)
+				if err != nil {
+					t.Fatalf("NewFile: %v", err)
+				}
+				if prefix := getEnumValuePrefix(file.Enums().Get(0)); prefix != expected {
+					t.Fatalf("expected %q, got %q", expected, prefix)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,28 @@
+package lockout
+
+import (
+	"time"
+)
+
+const (
+	// DefaultThreshold is the default number of failed authentications within the window that locks out a key
+	DefaultThreshold = 5
+
+	// DefaultWindow is the default window in which the failed authentications are counted
+	DefaultWindow = 15 * time.Minute
+
+	// DefaultLockoutDuration is the default duration of a lockout
+	DefaultLockoutDuration = 15 * time.Minute
+
+	// ClientIPKeyPrefix is the prefix of the store keys of the client IPs
+	ClientIPKeyPrefix = "ip:"
+
+	// CredentialKeyPrefix is the prefix of the store keys of the credential hashes
+	CredentialKeyPrefix = "credential:"
+
+	// DefaultCleanupInterval is the default interval to delete the expired entries of the in-memory store
+	DefaultCleanupInterval = time.Minute
+
+	// DefaultMaxEntries is the default maximum number of entries of the in-memory store
+	DefaultMaxEntries = 100000
+)
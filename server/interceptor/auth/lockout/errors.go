@@ -0,0 +1,11 @@
+package lockout
+
+import (
+	"errors"
+)
+
+var (
+	ErrNilAuthenticator      = errors.New("authenticator cannot be nil")
+	ErrNilStore              = errors.New("lockout store cannot be nil")
+	ErrTooManyFailedAttempts = errors.New("too many failed authentication attempts")
+)
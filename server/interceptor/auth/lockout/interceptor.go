@@ -0,0 +1,415 @@
+package lockout
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	gogrpc "github.com/ralvarezdev/go-grpc"
+	gogrpcservercontext "github.com/ralvarezdev/go-grpc/server/context"
+	gogrpcauth "github.com/ralvarezdev/go-grpc/server/interceptor/auth"
+)
+
+type (
+	// Interceptor is the guard that locks out the client IPs and the credentials with too many failed
+	// authentications of the wrapped authenticator
+	Interceptor struct {
+		unaryAuthenticator     grpc.UnaryServerInterceptor
+		streamAuthenticator    grpc.StreamServerInterceptor
+		methodInterceptor      gogrpcauth.MethodInterceptor
+		store                  Store
+		threshold              int
+		window                 time.Duration
+		lockoutDuration        time.Duration
+		credentialMetadataKeys []string
+		detailsGenerator       gogrpc.RetryInfoGenerator
+		logger                 *slog.Logger
+	}
+)
+
+// NewInterceptor creates a new lockout guard for an authenticator
+//
+// Parameters:
+//
+//   - authenticator: the authenticator to guard, if it is a gogrpcauth.MethodInterceptor the methods it does not
+//     intercept are not locked out
+//   - store: the store of the failed authentications
+//   - options: the options for the guard (optional, can be nil)
+//   - detailsGenerator: the retry info generator (optional, can be nil)
+//   - logger: the logger to use (optional, can be nil)
+//
+// Returns:
+//
+//   - *Interceptor: the interceptor
+//   - error: if the authenticator or the store are nil
+func NewInterceptor(
+	authenticator gogrpcauth.Authentication,
+	store Store,
+	options *Options,
+	detailsGenerator gogrpc.RetryInfoGenerator,
+	logger *slog.Logger,
+) (*Interceptor, error) {
+	// Check if either the authenticator or the store are nil
+	if authenticator == nil {
+		return nil, ErrNilAuthenticator
+	}
+	if store == nil {
+		return nil, ErrNilStore
+	}
+
+	// Set the default options
+	threshold := DefaultThreshold
+	window := DefaultWindow
+	lockoutDuration := DefaultLockoutDuration
+	credentialMetadataKeys := []string{
+		gogrpc.AuthorizationMetadataKey,
+		gogrpc.APIKeyMetadataKey,
+	}
+	if options != nil {
+		if options.Threshold > 0 {
+			threshold = options.Threshold
+		}
+		if options.Window > 0 {
+			window = options.Window
+		}
+		if options.LockoutDuration > 0 {
+			lockoutDuration = options.LockoutDuration
+		}
+		if len(options.CredentialMetadataKeys) > 0 {
+			credentialMetadataKeys = options.CredentialMetadataKeys
+		}
+	}
+
+	// Set the default error details generator
+	if detailsGenerator == nil {
+		detailsGenerator = gogrpc.NewDefaultErrorDetailsGenerator(logger)
+	}
+
+	if logger != nil {
+		logger = logger.With(
+			slog.String("grpc_server_interceptor", "auth_lockout"),
+		)
+	}
+
+	// Get the methods intercepted by the authenticator, if it can tell them
+	methodInterceptor, _ := authenticator.(gogrpcauth.MethodInterceptor)
+
+	return &Interceptor{
+		unaryAuthenticator:     authenticator.Authenticate(),
+		streamAuthenticator:    authenticator.AuthenticateStream(),
+		methodInterceptor:      methodInterceptor,
+		store:                  store,
+		threshold:              threshold,
+		window:                 window,
+		lockoutDuration:        lockoutDuration,
+		credentialMetadataKeys: credentialMetadataKeys,
+		detailsGenerator:       detailsGenerator,
+		logger:                 logger,
+	}, nil
+}
+
+// isGuarded checks if the given method is guarded, skipping the methods the authenticator does not intercept, such as
+// the health checks and the login methods
+//
+// Parameters:
+//
+//   - fullMethod: the full method name
+//
+// Returns:
+//
+//   - bool: true if the method is guarded, false otherwise
+func (i Interceptor) isGuarded(fullMethod string) bool {
+	return i.methodInterceptor == nil || i.methodInterceptor.Intercepts(fullMethod)
+}
+
+// isAuthenticated checks if the wrapped authenticator set the principal to the context
+//
+// Parameters:
+//
+//   - ctx: the context that reached the handler
+//
+// Returns:
+//
+//   - bool: true if the principal is set, false otherwise
+func isAuthenticated(ctx context.Context) bool {
+	_, err := gogrpcservercontext.GetPrincipal(ctx)
+	return err == nil
+}
+
+// getKeys gets the store keys of the client IP and the presented credential of the request
+//
+// Parameters:
+//
+//   - ctx: the context of the request
+//
+// Returns:
+//
+//   - string: the client IP key, or an empty string if the client IP is unknown
+//   - string: the credential key, or an empty string if no credential was presented
+func (i Interceptor) getKeys(ctx context.Context) (string, string) {
+	var ipKey, credentialKey string
+
+	// Get the client IP
+	if ip, err := gogrpcservercontext.GetClientIP(ctx); err == nil {
+		ipKey = ClientIPKeyPrefix + ip
+	}
+
+	// Hash the presented credentials
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ipKey, credentialKey
+	}
+	hash := sha256.New()
+	found := false
+	for _, key := range i.credentialMetadataKeys {
+		for _, value := range md.Get(key) {
+			found = true
+			hash.Write([]byte(key))
+			hash.Write([]byte{0})
+			hash.Write([]byte(value))
+			hash.Write([]byte{0})
+		}
+	}
+	if found {
+		credentialKey = CredentialKeyPrefix + hex.EncodeToString(hash.Sum(nil))
+	}
+	return ipKey, credentialKey
+}
+
+// check checks if any of the keys is locked out
+//
+// Parameters:
+//
+//   - ctx: the context of the request
+//   - fullMethod: the full method name of the request
+//   - keys: the store keys of the request
+//
+// Returns:
+//
+//   - error: a resource exhausted gRPC status error with the retry info, if any key is locked out
+func (i Interceptor) check(
+	ctx context.Context,
+	fullMethod string,
+	keys ...string,
+) error {
+	var lockedUntil time.Time
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		until, err := i.store.GetLockedUntil(ctx, key)
+		if err != nil {
+			if i.logger != nil {
+				i.logger.Error(
+					"Failed to get lockout",
+					slog.String("method", fullMethod),
+					slog.String("error", err.Error()),
+				)
+			}
+			continue
+		}
+		if until.After(lockedUntil) {
+			lockedUntil = until
+		}
+	}
+
+	// Check if any key is locked out
+	retryDelay := time.Until(lockedUntil)
+	if retryDelay <= 0 {
+		return nil
+	}
+
+	st := status.New(
+		codes.ResourceExhausted,
+		ErrTooManyFailedAttempts.Error(),
+	)
+	stWithDetails, err := st.WithDetails(i.detailsGenerator.NewRetryInfo(retryDelay))
+	if err != nil {
+		return st.Err()
+	}
+	return stWithDetails.Err()
+}
+
+// registerFailure registers a failed authentication for each key, locking out the ones that reach the threshold
+//
+// Parameters:
+//
+//   - ctx: the context of the request
+//   - fullMethod: the full method name of the request
+//   - keys: the store keys of the request
+func (i Interceptor) registerFailure(
+	ctx context.Context,
+	fullMethod string,
+	keys ...string,
+) {
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		failures, err := i.store.IncrementFailures(ctx, key, i.window)
+		if err != nil {
+			if i.logger != nil {
+				i.logger.Error(
+					"Failed to register failed authentication",
+					slog.String("method", fullMethod),
+					slog.String("error", err.Error()),
+				)
+			}
+			continue
+		}
+		if failures < i.threshold {
+			continue
+		}
+
+		// Lock out the key
+		if err = i.store.Lock(ctx, key, time.Now().Add(i.lockoutDuration)); err != nil {
+			if i.logger != nil {
+				i.logger.Error(
+					"Failed to lock out key",
+					slog.String("method", fullMethod),
+					slog.String("error", err.Error()),
+				)
+			}
+			continue
+		}
+		if i.logger != nil {
+			i.logger.Warn(
+				"Locked out after too many failed authentications",
+				slog.String("method", fullMethod),
+				slog.String("key", key),
+				slog.Int("failures", failures),
+			)
+		}
+	}
+}
+
+// registerResult registers the result of an authentication
+//
+// Parameters:
+//
+//   - ctx: the context of the request
+//   - fullMethod: the full method name of the request
+//   - authenticated: whether the authenticator reached the handler with the principal set
+//   - err: the error returned by the authenticator
+//   - ipKey: the client IP key
+//   - credentialKey: the credential key
+func (i Interceptor) registerResult(
+	ctx context.Context,
+	fullMethod string,
+	authenticated bool,
+	err error,
+	ipKey, credentialKey string,
+) {
+	// Reset the credential failures after a successful authentication
+	if authenticated {
+		if credentialKey != "" {
+			if resetErr := i.store.Reset(ctx, credentialKey); resetErr != nil && i.logger != nil {
+				i.logger.Error(
+					"Failed to reset failed authentications",
+					slog.String("method", fullMethod),
+					slog.String("error", resetErr.Error()),
+				)
+			}
+		}
+		return
+	}
+
+	// Only count the authentication failures
+	if status.Code(err) == codes.Unauthenticated {
+		i.registerFailure(ctx, fullMethod, ipKey, credentialKey)
+	}
+}
+
+// Authenticate returns the guarded authentication interceptor
+//
+// Returns:
+//
+//   - grpc.UnaryServerInterceptor: the interceptor
+func (i Interceptor) Authenticate() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req any, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		// Pass through the methods that are not intercepted by the authenticator
+		if !i.isGuarded(info.FullMethod) {
+			return i.unaryAuthenticator(ctx, req, info, handler)
+		}
+
+		// Check if the client IP or the credential are locked out
+		ipKey, credentialKey := i.getKeys(ctx)
+		if err := i.check(ctx, info.FullMethod, ipKey, credentialKey); err != nil {
+			return nil, err
+		}
+
+		// Authenticate the request, tracking if the handler was reached with the principal set, so the methods that
+		// are passed through without authentication do not reset the failures
+		authenticated := false
+		res, err := i.unaryAuthenticator(
+			ctx, req, info,
+			func(innerCtx context.Context, innerReq any) (any, error) {
+				authenticated = isAuthenticated(innerCtx)
+				return handler(innerCtx, innerReq)
+			},
+		)
+		i.registerResult(
+			ctx,
+			info.FullMethod,
+			authenticated,
+			err,
+			ipKey,
+			credentialKey,
+		)
+		return res, err
+	}
+}
+
+// AuthenticateStream returns the guarded stream authentication interceptor
+//
+// Returns:
+//
+//   - grpc.StreamServerInterceptor: the interceptor
+func (i Interceptor) AuthenticateStream() grpc.StreamServerInterceptor {
+	return func(
+		srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		// Pass through the methods that are not intercepted by the authenticator
+		if !i.isGuarded(info.FullMethod) {
+			return i.streamAuthenticator(srv, ss, info, handler)
+		}
+
+		// Check if the client IP or the credential are locked out
+		ctx := ss.Context()
+		ipKey, credentialKey := i.getKeys(ctx)
+		if err := i.check(ctx, info.FullMethod, ipKey, credentialKey); err != nil {
+			return err
+		}
+
+		// Authenticate the stream, tracking if the handler was reached with the principal set, so the methods that
+		// are passed through without authentication do not reset the failures
+		authenticated := false
+		err := i.streamAuthenticator(
+			srv, ss, info,
+			func(innerSrv any, innerSS grpc.ServerStream) error {
+				authenticated = isAuthenticated(innerSS.Context())
+				return handler(innerSrv, innerSS)
+			},
+		)
+		i.registerResult(
+			ctx,
+			info.FullMethod,
+			authenticated,
+			err,
+			ipKey,
+			credentialKey,
+		)
+		return err
+	}
+}
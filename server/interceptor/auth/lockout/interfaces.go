@@ -0,0 +1,16 @@
+package lockout
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// Store is the interface for the stores of the failed authentications, which can be shared between instances
+	Store interface {
+		IncrementFailures(ctx context.Context, key string, window time.Duration) (int, error)
+		Lock(ctx context.Context, key string, until time.Time) error
+		GetLockedUntil(ctx context.Context, key string) (time.Time, error)
+		Reset(ctx context.Context, key string) error
+	}
+)
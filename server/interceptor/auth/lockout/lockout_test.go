@@ -0,0 +1,258 @@
+package lockout
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	gogrpc "github.com/ralvarezdev/go-grpc"
+	gogrpcmd "github.com/ralvarezdev/go-grpc/metadata"
+	gogrpcapikey "github.com/ralvarezdev/go-grpc/server/interceptor/auth/apikey"
+)
+
+const (
+	testMethod       = "/pkg.Service/Method"
+	testPublicMethod = "/pkg.Service/Public"
+	testAPIKey       = "secret"
+	testThreshold    = 3
+)
+
+type (
+	// stubAPIKeyService accepts only the test API key
+	stubAPIKeyService struct{}
+)
+
+func (stubAPIKeyService) IsAPIKeyValid(apiKey string) bool {
+	return apiKey == testAPIKey
+}
+
+// newTestInterceptor creates a lockout guard of an API key authenticator that reads the x-api-key metadata and
+// intercepts only the test method
+func newTestInterceptor(t *testing.T) (*Interceptor, *MemoryStore) {
+	t.Helper()
+
+	interceptions, err := gogrpc.NewMethodSetMatcher([]string{testMethod})
+	if err != nil {
+		t.Fatalf("NewMethodSetMatcher: %v", err)
+	}
+	authenticator, err := gogrpcapikey.NewInterceptor(
+		stubAPIKeyService{},
+		interceptions,
+		gogrpcmd.NewAPIKeyTokenOptions(),
+	)
+	if err != nil {
+		t.Fatalf("NewInterceptor: %v", err)
+	}
+
+	store := NewMemoryStore(nil)
+	t.Cleanup(
+		func() {
+			_ = store.Close()
+		},
+	)
+	interceptor, err := NewInterceptor(
+		authenticator,
+		store,
+		&Options{Threshold: testThreshold},
+		nil,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("NewInterceptor: %v", err)
+	}
+	return interceptor, store
+}
+
+// call calls the guarded test method with the given API key from the given client IP
+func call(interceptor *Interceptor, method, ip, apiKey string) error {
+	ctx := peer.NewContext(
+		context.Background(),
+		&peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 1234}},
+	)
+	ctx = metadata.NewIncomingContext(
+		ctx,
+		metadata.Pairs(gogrpc.APIKeyMetadataKey, apiKey),
+	)
+	_, err := interceptor.Authenticate()(
+		ctx,
+		nil,
+		&grpc.UnaryServerInfo{FullMethod: method},
+		func(context.Context, any) (any, error) {
+			return nil, nil
+		},
+	)
+	return err
+}
+
+func TestLockoutAfterThreshold(t *testing.T) {
+	interceptor, _ := newTestInterceptor(t)
+
+	for range testThreshold {
+		if err := call(interceptor, testMethod, "10.0.0.1", "wrong"); status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("expected Unauthenticated, got %v", err)
+		}
+	}
+
+	// The client IP is locked out, even with a valid API key
+	err := call(interceptor, testMethod, "10.0.0.1", testAPIKey)
+	st := status.Convert(err)
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+	hasRetryInfo := false
+	for _, detail := range st.Details() {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok && retryInfo.GetRetryDelay().AsDuration() > 0 {
+			hasRetryInfo = true
+		}
+	}
+	if !hasRetryInfo {
+		t.Fatal("expected a retry info detail")
+	}
+
+	// The wrong API key is locked out from other client IPs too, since it is read from x-api-key by default
+	if err = call(interceptor, testMethod, "10.0.0.2", "wrong"); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted for the locked out API key, got %v", err)
+	}
+
+	// Other client IPs and API keys are not affected
+	if err = call(interceptor, testMethod, "10.0.0.2", testAPIKey); err != nil {
+		t.Fatalf("expected the valid API key to authenticate, got %v", err)
+	}
+}
+
+func TestSuccessResetsCredentialFailures(t *testing.T) {
+	interceptor, store := newTestInterceptor(t)
+	ctx := context.Background()
+
+	// Count failures of the valid API key below the threshold
+	key := credentialKey(t, interceptor, testAPIKey)
+	for range testThreshold - 1 {
+		if _, err := store.IncrementFailures(ctx, key, time.Hour); err != nil {
+			t.Fatalf("IncrementFailures: %v", err)
+		}
+	}
+	if err := call(interceptor, testMethod, "10.0.0.1", testAPIKey); err != nil {
+		t.Fatalf("expected the valid API key to authenticate, got %v", err)
+	}
+	failures, err := store.IncrementFailures(ctx, key, time.Hour)
+	if err != nil {
+		t.Fatalf("IncrementFailures: %v", err)
+	}
+	if failures != 1 {
+		t.Fatalf("expected the failures to be reset, got %d", failures)
+	}
+}
+
+func TestPassThroughDoesNotResetFailures(t *testing.T) {
+	interceptor, _ := newTestInterceptor(t)
+
+	for n := range testThreshold {
+		// Alternate the failures with calls to a method that is not intercepted
+		if err := call(interceptor, testPublicMethod, fmt.Sprintf("10.0.1.%d", n), "wrong"); err != nil {
+			t.Fatalf("expected the public method to pass through, got %v", err)
+		}
+		if err := call(
+			interceptor,
+			testMethod,
+			fmt.Sprintf("10.0.2.%d", n),
+			"wrong",
+		); status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("expected Unauthenticated, got %v", err)
+		}
+	}
+	if err := call(interceptor, testMethod, "10.0.3.1", "wrong"); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected the API key to be locked out, got %v", err)
+	}
+}
+
+func TestLockedOutIPReachesPublicMethod(t *testing.T) {
+	interceptor, _ := newTestInterceptor(t)
+
+	for range testThreshold {
+		if err := call(interceptor, testMethod, "10.0.0.1", "wrong"); status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("expected Unauthenticated, got %v", err)
+		}
+	}
+	if err := call(interceptor, testMethod, "10.0.0.1", testAPIKey); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected the client IP to be locked out, got %v", err)
+	}
+
+	// The methods that are not intercepted by the authenticator are not locked out
+	if err := call(interceptor, testPublicMethod, "10.0.0.1", "wrong"); err != nil {
+		t.Fatalf("expected the locked out client IP to reach the public method, got %v", err)
+	}
+}
+
+// credentialKey returns the store key of the given API key
+func credentialKey(t *testing.T, interceptor *Interceptor, apiKey string) string {
+	t.Helper()
+
+	_, key := interceptor.getKeys(
+		metadata.NewIncomingContext(
+			context.Background(),
+			metadata.Pairs(gogrpc.APIKeyMetadataKey, apiKey),
+		),
+	)
+	if key == "" {
+		t.Fatal("expected a credential key")
+	}
+	return key
+}
+
+func TestMemoryStoreMaxEntries(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(&MemoryStoreOptions{MaxEntries: 3})
+	defer func() {
+		_ = store.Close()
+	}()
+
+	if err := store.Lock(ctx, "locked", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+	for n := range 10 {
+		if _, err := store.IncrementFailures(ctx, fmt.Sprintf("key-%d", n), time.Hour); err != nil {
+			t.Fatalf("IncrementFailures: %v", err)
+		}
+	}
+
+	if length := store.Len(); length != 3 {
+		t.Fatalf("expected the store to be capped at 3 entries, got %d", length)
+	}
+	lockedUntil, err := store.GetLockedUntil(ctx, "locked")
+	if err != nil || lockedUntil.IsZero() {
+		t.Fatalf("expected the locked out entry not to be evicted, got %v (%v)", lockedUntil, err)
+	}
+}
+
+func TestMemoryStoreCleanup(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(&MemoryStoreOptions{CleanupInterval: 10 * time.Millisecond})
+	defer func() {
+		_ = store.Close()
+	}()
+
+	if _, err := store.IncrementFailures(ctx, "key", 10*time.Millisecond); err != nil {
+		t.Fatalf("IncrementFailures: %v", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for store.Len() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the expired entry to be deleted by the cleanup goroutine")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// Closing twice is safe
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
@@ -0,0 +1,282 @@
+package lockout
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type (
+	// memoryEntry is an entry of the in-memory store
+	memoryEntry struct {
+		failures    int
+		windowEnd   time.Time
+		lockedUntil time.Time
+	}
+
+	// MemoryStore is an in-memory store of the failed authentications
+	//
+	// The expired entries are deleted periodically by a background goroutine, stopped with Close, and the number of
+	// entries is capped, evicting the unlocked entries whose window ends first when the store is full
+	MemoryStore struct {
+		mutex      sync.Mutex
+		entries    map[string]*memoryEntry
+		maxEntries int
+		closeOnce  sync.Once
+		closed     chan struct{}
+	}
+)
+
+// NewMemoryStore creates a new in-memory store of the failed authentications and starts its cleanup goroutine
+//
+// Parameters:
+//
+//   - options: the options for the in-memory store (optional, can be nil)
+//
+// Returns:
+//
+//   - *MemoryStore: the in-memory store
+func NewMemoryStore(options *MemoryStoreOptions) *MemoryStore {
+	// Set the default options
+	cleanupInterval := DefaultCleanupInterval
+	maxEntries := DefaultMaxEntries
+	if options != nil {
+		if options.CleanupInterval > 0 {
+			cleanupInterval = options.CleanupInterval
+		}
+		if options.MaxEntries > 0 {
+			maxEntries = options.MaxEntries
+		}
+	}
+
+	m := &MemoryStore{
+		entries:    make(map[string]*memoryEntry),
+		maxEntries: maxEntries,
+		closed:     make(chan struct{}),
+	}
+	go m.cleanup(cleanupInterval)
+	return m
+}
+
+// cleanup deletes the expired entries every given interval until the store is closed
+//
+// Parameters:
+//
+//   - interval: the cleanup interval
+func (m *MemoryStore) cleanup(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.DeleteExpired()
+		case <-m.closed:
+			return
+		}
+	}
+}
+
+// Close stops the cleanup goroutine of the store
+//
+// Returns:
+//
+//   - error: always nil
+func (m *MemoryStore) Close() error {
+	m.closeOnce.Do(
+		func() {
+			close(m.closed)
+		},
+	)
+	return nil
+}
+
+// getOrCreateEntry gets the entry of the given key, creating it if it does not exist and evicting an entry if the
+// store is full. It must be called with the mutex locked
+//
+// Parameters:
+//
+//   - key: the key
+//   - now: the current time
+//
+// Returns:
+//
+//   - *memoryEntry: the entry
+func (m *MemoryStore) getOrCreateEntry(key string, now time.Time) *memoryEntry {
+	if entry, ok := m.entries[key]; ok {
+		return entry
+	}
+
+	// Make room for the new entry
+	if len(m.entries) >= m.maxEntries {
+		m.deleteExpired(now)
+	}
+	if len(m.entries) >= m.maxEntries {
+		m.evict(now)
+	}
+
+	entry := &memoryEntry{}
+	m.entries[key] = entry
+	return entry
+}
+
+// evict evicts the unlocked entry whose window ends first or, if every entry is locked out, the entry whose lockout
+// ends first. It must be called with the mutex locked
+//
+// Parameters:
+//
+//   - now: the current time
+func (m *MemoryStore) evict(now time.Time) {
+	var evictKey string
+	var evictEntry *memoryEntry
+	for key, entry := range m.entries {
+		if evictEntry == nil {
+			evictKey, evictEntry = key, entry
+			continue
+		}
+
+		// Prefer the unlocked entries over the locked out ones
+		locked := entry.lockedUntil.After(now)
+		evictLocked := evictEntry.lockedUntil.After(now)
+		switch {
+		case locked != evictLocked:
+			if !locked {
+				evictKey, evictEntry = key, entry
+			}
+		case locked:
+			if entry.lockedUntil.Before(evictEntry.lockedUntil) {
+				evictKey, evictEntry = key, entry
+			}
+		default:
+			if entry.windowEnd.Before(evictEntry.windowEnd) {
+				evictKey, evictEntry = key, entry
+			}
+		}
+	}
+	if evictEntry != nil {
+		delete(m.entries, evictKey)
+	}
+}
+
+// IncrementFailures increments the failed authentications of the given key within the window
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - key: the key
+//   - window: the window in which the failed authentications are counted
+//
+// Returns:
+//
+//   - int: the failed authentications of the key within the current window
+//   - error: always nil
+func (m *MemoryStore) IncrementFailures(
+	ctx context.Context,
+	key string,
+	window time.Duration,
+) (int, error) {
+	now := time.Now()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	entry := m.getOrCreateEntry(key, now)
+
+	// Start a new window if the current one has ended
+	if !entry.windowEnd.After(now) {
+		entry.failures = 0
+		entry.windowEnd = now.Add(window)
+	}
+	entry.failures++
+	return entry.failures, nil
+}
+
+// Lock locks out the given key until the given time
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - key: the key
+//   - until: the time at which the lockout expires
+//
+// Returns:
+//
+//   - error: always nil
+func (m *MemoryStore) Lock(ctx context.Context, key string, until time.Time) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	entry := m.getOrCreateEntry(key, time.Now())
+	entry.lockedUntil = until
+	return nil
+}
+
+// GetLockedUntil gets the time at which the lockout of the given key expires
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - key: the key
+//
+// Returns:
+//
+//   - time.Time: the time at which the lockout expires, or the zero time if the key is not locked out
+//   - error: always nil
+func (m *MemoryStore) GetLockedUntil(ctx context.Context, key string) (
+	time.Time,
+	error,
+) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	entry, ok := m.entries[key]
+	if !ok || !entry.lockedUntil.After(time.Now()) {
+		return time.Time{}, nil
+	}
+	return entry.lockedUntil, nil
+}
+
+// Reset resets the failed authentications and the lockout of the given key
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - key: the key
+//
+// Returns:
+//
+//   - error: always nil
+func (m *MemoryStore) Reset(ctx context.Context, key string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.entries, key)
+	return nil
+}
+
+// deleteExpired deletes the entries whose window and lockout have expired. It must be called with the mutex locked
+//
+// Parameters:
+//
+//   - now: the current time
+func (m *MemoryStore) deleteExpired(now time.Time) {
+	for key, entry := range m.entries {
+		if !entry.windowEnd.After(now) && !entry.lockedUntil.After(now) {
+			delete(m.entries, key)
+		}
+	}
+}
+
+// DeleteExpired deletes the entries whose window and lockout have expired
+func (m *MemoryStore) DeleteExpired() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.deleteExpired(time.Now())
+}
+
+// Len returns the number of entries of the store
+//
+// Returns:
+//
+//   - int: the number of entries
+func (m *MemoryStore) Len() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return len(m.entries)
+}
@@ -0,0 +1,33 @@
+package lockout
+
+import (
+	"time"
+)
+
+type (
+	// Options are the options for the lockout guard
+	Options struct {
+		// Threshold is the number of failed authentications within the window that locks out a key
+		Threshold int
+
+		// Window is the window in which the failed authentications are counted
+		Window time.Duration
+
+		// LockoutDuration is the duration of a lockout
+		LockoutDuration time.Duration
+
+		// CredentialMetadataKeys are the metadata keys of the presented credentials, if empty the authorization and
+		// the API key metadata keys are used
+		CredentialMetadataKeys []string
+	}
+
+	// MemoryStoreOptions are the options for the in-memory store
+	MemoryStoreOptions struct {
+		// CleanupInterval is the interval to delete the expired entries
+		CleanupInterval time.Duration
+
+		// MaxEntries is the maximum number of entries, when it is reached the unlocked entries whose window ends
+		// first are evicted
+		MaxEntries int
+	}
+)
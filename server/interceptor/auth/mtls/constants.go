@@ -0,0 +1,33 @@
+package mtls
+
+import (
+	gogrpcservercontext "github.com/ralvarezdev/go-grpc/server/context"
+)
+
+const (
+	// SPIFFEScheme is the URI scheme of the SPIFFE IDs
+	SPIFFEScheme = "spiffe"
+
+	// AnyIdentity is the allowed identity that matches any authenticated client certificate
+	AnyIdentity = "*"
+
+	// CommonNameIdentityPrefix is the prefix of the allowed identities that match the subject common name, e.g.
+	// "cn:billing"
+	CommonNameIdentityPrefix = "cn:"
+
+	// DNSIdentityPrefix is the prefix of the allowed identities that match the DNS SANs, e.g.
+	// "dns:billing.example.org"
+	DNSIdentityPrefix = "dns:"
+
+	// URIIdentityPrefix is the prefix of the allowed identities that match the URI SANs, including the SPIFFE ID,
+	// e.g. "uri:spiffe://example.org/ns/prod/sa/billing"
+	URIIdentityPrefix = "uri:"
+
+	// WildcardIdentitySuffix is the suffix of the allowed identities that match by prefix
+	WildcardIdentitySuffix = "*"
+)
+
+var (
+	// CtxIdentityKey is the key for the client certificate identity to be set to the context
+	CtxIdentityKey gogrpcservercontext.CtxKey = "mtls_identity"
+)
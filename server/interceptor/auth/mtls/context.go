@@ -0,0 +1,44 @@
+package mtls
+
+import (
+	"context"
+)
+
+// SetCtxIdentity sets the client certificate identity to the context
+//
+// Parameters:
+//
+//   - ctx: The context to set the identity to
+//   - identity: The identity to set
+//
+// Returns:
+//
+//   - context.Context: The context with the identity set
+func SetCtxIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, CtxIdentityKey, identity)
+}
+
+// GetCtxIdentity gets the client certificate identity from the context
+//
+// Parameters:
+//
+//   - ctx: The context to get the identity from
+//
+// Returns:
+//
+//   - *Identity: The identity
+//   - error: An error if the identity is not found or is of an unexpected type
+func GetCtxIdentity(ctx context.Context) (*Identity, error) {
+	// Get the identity from the context
+	value := ctx.Value(CtxIdentityKey)
+	if value == nil {
+		return nil, ErrMissingIdentityInContext
+	}
+
+	// Check the type of the value
+	identity, ok := value.(*Identity)
+	if !ok {
+		return nil, ErrUnexpectedIdentityTypeInContext
+	}
+	return identity, nil
+}
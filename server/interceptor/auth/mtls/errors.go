@@ -0,0 +1,14 @@
+package mtls
+
+import (
+	"errors"
+)
+
+var (
+	ErrMissingPeer                      = errors.New("missing peer information")
+	ErrMissingTLSInfo                   = errors.New("missing TLS information")
+	ErrMissingVerifiedClientCertificate = errors.New("missing verified client certificate")
+	ErrIdentityNotAllowed               = errors.New("client certificate identity not allowed")
+	ErrMissingIdentityInContext         = errors.New("missing client certificate identity in context")
+	ErrUnexpectedIdentityTypeInContext  = errors.New("unexpected client certificate identity type in context")
+)
@@ -0,0 +1,214 @@
+package mtls
+
+import (
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	gogrpc "github.com/ralvarezdev/go-grpc"
+	gogrpcservercontext "github.com/ralvarezdev/go-grpc/server/context"
+	gogrpcserverstream "github.com/ralvarezdev/go-grpc/server/stream"
+)
+
+type (
+	// Interceptor is the interceptor for the mutual TLS client certificate authentication
+	Interceptor struct {
+		interceptions *gogrpc.MethodMatcher[[]string]
+		logger        *slog.Logger
+	}
+)
+
+// NewInterceptor creates a new mutual TLS authentication interceptor
+//
+// Parameters:
+//
+//   - interceptions: the method matcher of the identities allowed to call each method, see Identity.Matches
+//   - logger: the logger to use (optional, can be nil)
+//
+// Returns:
+//
+//   - *Interceptor: the interceptor
+//   - error: if the interceptions are nil
+func NewInterceptor(
+	interceptions *gogrpc.MethodMatcher[[]string],
+	logger *slog.Logger,
+) (*Interceptor, error) {
+	// Check if the interceptions are nil
+	if interceptions == nil {
+		return nil, gogrpc.ErrNilInterceptions
+	}
+
+	if logger != nil {
+		logger = logger.With(
+			slog.String("grpc_server_interceptor", "mtls_authenticator"),
+		)
+	}
+
+	return &Interceptor{
+		interceptions: interceptions,
+		logger:        logger,
+	}, nil
+}
+
+// GetPeerIdentity gets the identity of the verified client certificate of the peer
+//
+// Parameters:
+//
+//   - ctx: the context of the request
+//
+// Returns:
+//
+//   - *Identity: the identity of the client certificate
+//   - error: if the peer, its TLS information or its verified client certificate are missing
+func GetPeerIdentity(ctx context.Context) (*Identity, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, ErrMissingPeer
+	}
+
+	// Get the TLS information of the peer
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return nil, ErrMissingTLSInfo
+	}
+
+	// Only trust the client certificates that were verified during the handshake
+	if len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil, ErrMissingVerifiedClientCertificate
+	}
+	return NewIdentity(tlsInfo.State.VerifiedChains[0][0]), nil
+}
+
+// authenticate checks the client certificate identity of the given method
+//
+// Parameters:
+//
+//   - ctx: the context of the request
+//   - fullMethod: the full method name of the request
+//
+// Returns:
+//
+//   - context.Context: the context with the identity and the principal set, if the method is intercepted
+//   - error: a gRPC status error if the authentication failed
+func (i Interceptor) authenticate(
+	ctx context.Context,
+	fullMethod string,
+) (context.Context, error) {
+	// Check if the method should be intercepted
+	allowed, ok := i.interceptions.Match(fullMethod)
+	if !ok {
+		return ctx, nil
+	}
+
+	// Get the identity of the client certificate
+	identity, err := GetPeerIdentity(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	// Check if the identity is allowed, an empty list allows any verified client certificate
+	if len(allowed) > 0 && !identity.Matches(allowed) {
+		if i.logger != nil {
+			i.logger.Warn(
+				"Client certificate identity not allowed",
+				slog.String("method", fullMethod),
+				slog.String("identity", identity.Name),
+			)
+		}
+		return nil, status.Error(
+			codes.PermissionDenied,
+			ErrIdentityNotAllowed.Error(),
+		)
+	}
+
+	// Set the identity and the principal to the context
+	ctx = SetCtxIdentity(ctx, identity)
+	ctx = gogrpcservercontext.SetCtxPrincipal(ctx, identity.Principal())
+	return ctx, nil
+}
+
+// Intercepts checks if the given method is intercepted
+//
+// Parameters:
+//
+//   - fullMethod: the full method name
+//
+// Returns:
+//
+//   - bool: true if the method is intercepted, false otherwise
+func (i Interceptor) Intercepts(fullMethod string) bool {
+	_, ok := i.interceptions.Match(fullMethod)
+	return ok
+}
+
+// AuthenticateCtx authenticates a request of the given method without passing through the methods that are not
+// intercepted
+//
+// Parameters:
+//
+//   - ctx: the context of the request
+//   - fullMethod: the full method name of the request
+//
+// Returns:
+//
+//   - context.Context: the context with the client certificate identity and principal set
+//   - error: gogrpc.ErrMethodNotIntercepted if the method is not intercepted, or a gRPC status error if the
+//     authentication failed
+func (i Interceptor) AuthenticateCtx(
+	ctx context.Context,
+	fullMethod string,
+) (context.Context, error) {
+	// Check if the method is intercepted
+	if !i.Intercepts(fullMethod) {
+		return nil, gogrpc.ErrMethodNotIntercepted
+	}
+	return i.authenticate(ctx, fullMethod)
+}
+
+// Authenticate returns the mutual TLS authentication interceptor
+//
+// Returns:
+//
+//   - grpc.UnaryServerInterceptor: the interceptor
+func (i Interceptor) Authenticate() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req any, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		// Authenticate the request
+		ctx, err := i.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AuthenticateStream returns the mutual TLS stream authentication interceptor
+//
+// Returns:
+//
+//   - grpc.StreamServerInterceptor: the interceptor
+func (i Interceptor) AuthenticateStream() grpc.StreamServerInterceptor {
+	return func(
+		srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		// Authenticate the stream
+		ctx, err := i.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		// Wrap the server stream with the authenticated context
+		return handler(
+			srv,
+			gogrpcserverstream.NewWrappedServerStream(ctx, ss),
+		)
+	}
+}
@@ -0,0 +1,326 @@
+package mtls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"net"
+	"net/url"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	gogrpc "github.com/ralvarezdev/go-grpc"
+	gogrpcservercontext "github.com/ralvarezdev/go-grpc/server/context"
+)
+
+const (
+	testMethod       = "/pkg.Service/Method"
+	testPublicMethod = "/pkg.Service/Public"
+	testSPIFFEID     = "spiffe://example.org/ns/prod/sa/billing"
+)
+
+// newTestCertificate creates a client certificate with the given common name, DNS SANs and URI SANs
+func newTestCertificate(t *testing.T, commonName string, dnsNames []string, uris ...string) *x509.Certificate {
+	t.Helper()
+
+	certificate := &x509.Certificate{
+		Subject:  pkix.Name{CommonName: commonName},
+		DNSNames: dnsNames,
+	}
+	for _, uri := range uris {
+		parsedURI, err := url.Parse(uri)
+		if err != nil {
+			t.Fatalf("url.Parse: %v", err)
+		}
+		certificate.URIs = append(certificate.URIs, parsedURI)
+	}
+	return certificate
+}
+
+// newPeerContext creates a context with a peer whose TLS handshake verified the given client certificate
+func newPeerContext(certificate *x509.Certificate) context.Context {
+	state := tls.ConnectionState{}
+	if certificate != nil {
+		state.VerifiedChains = [][]*x509.Certificate{{certificate}}
+	}
+	return peer.NewContext(
+		context.Background(),
+		&peer.Peer{
+			Addr:     &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234},
+			AuthInfo: credentials.TLSInfo{State: state},
+		},
+	)
+}
+
+func TestNewIdentity(t *testing.T) {
+	tests := []struct {
+		name        string
+		certificate *x509.Certificate
+		wantName    string
+		wantSPIFFE  string
+	}{
+		{
+			name: "SPIFFE ID",
+			certificate: newTestCertificate(
+				t,
+				"billing",
+				[]string{"billing.example.org"},
+				"https://example.org/billing",
+				testSPIFFEID,
+			),
+			wantName:   testSPIFFEID,
+			wantSPIFFE: testSPIFFEID,
+		},
+		{
+			name: "URI SAN",
+			certificate: newTestCertificate(
+				t,
+				"billing",
+				[]string{"billing.example.org"},
+				"https://example.org/billing",
+			),
+			wantName: "https://example.org/billing",
+		},
+		{
+			name:        "DNS SAN",
+			certificate: newTestCertificate(t, "billing", []string{"billing.example.org"}),
+			wantName:    "billing.example.org",
+		},
+		{
+			name:        "common name",
+			certificate: newTestCertificate(t, "billing", nil),
+			wantName:    "billing",
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				identity := NewIdentity(test.certificate)
+				if identity.Name != test.wantName || identity.SPIFFEID != test.wantSPIFFE {
+					t.Fatalf(
+						"expected (%q, %q), got (%q, %q)",
+						test.wantName,
+						test.wantSPIFFE,
+						identity.Name,
+						identity.SPIFFEID,
+					)
+				}
+			},
+		)
+	}
+}
+
+func TestIdentityMatches(t *testing.T) {
+	identity := NewIdentity(
+		newTestCertificate(
+			t,
+			"billing.example.org",
+			[]string{"api.example.org"},
+			testSPIFFEID,
+		),
+	)
+	tests := []struct {
+		name     string
+		allowed  []string
+		expected bool
+	}{
+		{
+			name:     "any identity",
+			allowed:  []string{AnyIdentity},
+			expected: true,
+		},
+		{
+			name:     "common name",
+			allowed:  []string{"cn:billing.example.org"},
+			expected: true,
+		},
+		{
+			name:     "DNS SAN",
+			allowed:  []string{"dns:api.example.org"},
+			expected: true,
+		},
+		{
+			name:     "URI SAN",
+			allowed:  []string{"uri:" + testSPIFFEID},
+			expected: true,
+		},
+		{
+			name:     "URI SAN wildcard",
+			allowed:  []string{"uri:spiffe://example.org/ns/prod/*"},
+			expected: true,
+		},
+		{
+			name:     "DNS SAN wildcard",
+			allowed:  []string{"dns:api.*"},
+			expected: true,
+		},
+		{
+			name:    "DNS entry does not match the common name",
+			allowed: []string{"dns:billing.example.org"},
+		},
+		{
+			name:    "common name entry does not match the DNS SAN",
+			allowed: []string{"cn:api.example.org"},
+		},
+		{
+			name:    "DNS entry does not match the URI SAN",
+			allowed: []string{"dns:" + testSPIFFEID},
+		},
+		{
+			name:    "URI wildcard does not match the other namespaces",
+			allowed: []string{"uri:spiffe://example.org/ns/dev/*"},
+		},
+		{
+			name:    "untyped entries never match",
+			allowed: []string{"billing.example.org", "api.example.org", testSPIFFEID, "c*"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				if matched := identity.Matches(test.allowed); matched != test.expected {
+					t.Fatalf("expected %v, got %v", test.expected, matched)
+				}
+			},
+		)
+	}
+}
+
+// authenticate runs the interceptor for the given method and returns the context reaching the handler
+func authenticate(
+	t *testing.T,
+	ctx context.Context,
+	method string,
+) (context.Context, error) {
+	t.Helper()
+
+	interceptions, err := gogrpc.NewMethodMatcher(
+		map[string][]string{
+			testMethod: {"uri:spiffe://example.org/ns/prod/*"},
+		},
+	)
+	if err != nil {
+		t.Fatalf("NewMethodMatcher: %v", err)
+	}
+	interceptor, err := NewInterceptor(interceptions, nil)
+	if err != nil {
+		t.Fatalf("NewInterceptor: %v", err)
+	}
+
+	var handlerCtx context.Context
+	_, err = interceptor.Authenticate()(
+		ctx,
+		nil,
+		&grpc.UnaryServerInfo{FullMethod: method},
+		func(innerCtx context.Context, _ any) (any, error) {
+			handlerCtx = innerCtx
+			return nil, nil
+		},
+	)
+	return handlerCtx, err
+}
+
+func TestAuthenticate(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected codes.Code
+	}{
+		{
+			name:     "missing peer",
+			ctx:      context.Background(),
+			expected: codes.Unauthenticated,
+		},
+		{
+			name: "missing TLS information",
+			ctx: peer.NewContext(
+				context.Background(),
+				&peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234}},
+			),
+			expected: codes.Unauthenticated,
+		},
+		{
+			name:     "missing verified client certificate",
+			ctx:      newPeerContext(nil),
+			expected: codes.Unauthenticated,
+		},
+		{
+			name: "identity not allowed",
+			ctx: newPeerContext(
+				newTestCertificate(t, "spiffe://example.org/ns/prod/sa/billing", nil),
+			),
+			expected: codes.PermissionDenied,
+		},
+		{
+			name:     "identity allowed",
+			ctx:      newPeerContext(newTestCertificate(t, "billing", nil, testSPIFFEID)),
+			expected: codes.OK,
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				handlerCtx, err := authenticate(t, test.ctx, testMethod)
+				if status.Code(err) != test.expected {
+					t.Fatalf("expected %v, got %v", test.expected, err)
+				}
+				if test.expected != codes.OK {
+					return
+				}
+
+				// Check the identity and the principal set to the context
+				identity, err := GetCtxIdentity(handlerCtx)
+				if err != nil || identity.SPIFFEID != testSPIFFEID {
+					t.Fatalf("expected the identity, got %v (%v)", identity, err)
+				}
+				principal, err := gogrpcservercontext.GetPrincipal(handlerCtx)
+				if err != nil || principal.Subject != testSPIFFEID ||
+					principal.AuthScheme != gogrpcservercontext.AuthSchemeMTLS {
+					t.Fatalf("expected the mTLS principal, got %+v (%v)", principal, err)
+				}
+			},
+		)
+	}
+}
+
+func TestAuthenticatePublicMethod(t *testing.T) {
+	handlerCtx, err := authenticate(t, context.Background(), testPublicMethod)
+	if err != nil {
+		t.Fatalf("expected the public method to pass through, got %v", err)
+	}
+	if _, err = GetCtxIdentity(handlerCtx); !errors.Is(err, ErrMissingIdentityInContext) {
+		t.Fatalf("expected no identity, got %v", err)
+	}
+}
+
+func TestAuthenticateCtxNotIntercepted(t *testing.T) {
+	interceptions, err := gogrpc.NewMethodMatcher(map[string][]string{testMethod: nil})
+	if err != nil {
+		t.Fatalf("NewMethodMatcher: %v", err)
+	}
+	interceptor, err := NewInterceptor(interceptions, nil)
+	if err != nil {
+		t.Fatalf("NewInterceptor: %v", err)
+	}
+
+	ctx := newPeerContext(newTestCertificate(t, "billing", nil))
+	if _, err = interceptor.AuthenticateCtx(ctx, testPublicMethod); !errors.Is(err, gogrpc.ErrMethodNotIntercepted) {
+		t.Fatalf("expected ErrMethodNotIntercepted, got %v", err)
+	}
+
+	// An empty list of allowed identities allows any verified client certificate
+	authCtx, err := interceptor.AuthenticateCtx(ctx, testMethod)
+	if err != nil {
+		t.Fatalf("AuthenticateCtx: %v", err)
+	}
+	if identity, err := GetCtxIdentity(authCtx); err != nil || identity.CommonName != "billing" {
+		t.Fatalf("expected the identity, got %v (%v)", identity, err)
+	}
+}
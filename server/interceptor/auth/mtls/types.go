@@ -0,0 +1,167 @@
+package mtls
+
+import (
+	"crypto/x509"
+	"slices"
+	"strings"
+
+	gogrpcservercontext "github.com/ralvarezdev/go-grpc/server/context"
+)
+
+type (
+	// Identity is the identity of a caller authenticated by its client certificate
+	Identity struct {
+		// Name is the primary identity of the caller: the SPIFFE ID, the first URI SAN, the first DNS SAN or the
+		// subject common name, in that order of preference
+		Name string
+
+		// CommonName is the subject common name of the certificate
+		CommonName string
+
+		// DNSNames are the DNS SANs of the certificate
+		DNSNames []string
+
+		// URIs are the URI SANs of the certificate
+		URIs []string
+
+		// SPIFFEID is the SPIFFE ID of the certificate, if any
+		SPIFFEID string
+	}
+)
+
+// NewIdentity creates a new identity from a client certificate
+//
+// Parameters:
+//
+//   - certificate: the client certificate
+//
+// Returns:
+//
+//   - *Identity: the identity
+func NewIdentity(certificate *x509.Certificate) *Identity {
+	identity := &Identity{
+		CommonName: certificate.Subject.CommonName,
+		DNSNames:   certificate.DNSNames,
+	}
+
+	// Get the URI SANs and the SPIFFE ID
+	for _, uri := range certificate.URIs {
+		if uri == nil {
+			continue
+		}
+		identity.URIs = append(identity.URIs, uri.String())
+		if identity.SPIFFEID == "" && uri.Scheme == SPIFFEScheme {
+			identity.SPIFFEID = uri.String()
+		}
+	}
+
+	// Set the primary identity
+	switch {
+	case identity.SPIFFEID != "":
+		identity.Name = identity.SPIFFEID
+	case len(identity.URIs) > 0:
+		identity.Name = identity.URIs[0]
+	case len(identity.DNSNames) > 0:
+		identity.Name = identity.DNSNames[0]
+	default:
+		identity.Name = identity.CommonName
+	}
+	return identity
+}
+
+// Values returns every value the identity can be matched against, prefixed by their type so a value of one type
+// cannot match an allowed identity of another type
+//
+// Returns:
+//
+//   - []string: the URI SANs, including the SPIFFE ID, prefixed by URIIdentityPrefix, the DNS SANs prefixed by
+//     DNSIdentityPrefix and the subject common name prefixed by CommonNameIdentityPrefix
+func (i Identity) Values() []string {
+	values := make([]string, 0, len(i.URIs)+len(i.DNSNames)+1)
+	for _, uri := range i.URIs {
+		values = append(values, URIIdentityPrefix+uri)
+	}
+	for _, dnsName := range i.DNSNames {
+		values = append(values, DNSIdentityPrefix+dnsName)
+	}
+	if i.CommonName != "" {
+		values = append(values, CommonNameIdentityPrefix+i.CommonName)
+	}
+	return values
+}
+
+// hasIdentityType checks if the allowed identity has a type prefix
+//
+// Parameters:
+//
+//   - allowed: the allowed identity
+//
+// Returns:
+//
+//   - bool: true if the allowed identity is prefixed by CommonNameIdentityPrefix, DNSIdentityPrefix or
+//     URIIdentityPrefix, false otherwise
+func hasIdentityType(allowed string) bool {
+	return strings.HasPrefix(allowed, CommonNameIdentityPrefix) ||
+		strings.HasPrefix(allowed, DNSIdentityPrefix) ||
+		strings.HasPrefix(allowed, URIIdentityPrefix)
+}
+
+// Matches checks if the identity matches any of the allowed identities
+//
+// An allowed identity can be AnyIdentity, or a typed exact value or prefix ending with WildcardIdentitySuffix. The
+// type is set by the CommonNameIdentityPrefix, DNSIdentityPrefix or URIIdentityPrefix prefixes, e.g.
+// "uri:spiffe://example.org/ns/prod/*" or "dns:billing.example.org". The allowed identities without a type prefix
+// never match
+//
+// Parameters:
+//
+//   - allowed: the allowed identities
+//
+// Returns:
+//
+//   - bool: true if the identity matches any of the allowed identities, false otherwise
+func (i Identity) Matches(allowed []string) bool {
+	values := i.Values()
+	for _, a := range allowed {
+		if a == AnyIdentity {
+			return true
+		}
+
+		// Skip the allowed identities without a type prefix
+		if !hasIdentityType(a) {
+			continue
+		}
+		if prefix, ok := strings.CutSuffix(a, WildcardIdentitySuffix); ok {
+			if slices.ContainsFunc(
+				values, func(value string) bool {
+					return strings.HasPrefix(value, prefix)
+				},
+			) {
+				return true
+			}
+			continue
+		}
+		if slices.Contains(values, a) {
+			return true
+		}
+	}
+	return false
+}
+
+// Principal returns the principal of the identity
+//
+// Returns:
+//
+//   - *gogrpcservercontext.Principal: the principal
+func (i Identity) Principal() *gogrpcservercontext.Principal {
+	return &gogrpcservercontext.Principal{
+		Subject:    i.Name,
+		AuthScheme: gogrpcservercontext.AuthSchemeMTLS,
+		Attributes: map[string]any{
+			"common_name": i.CommonName,
+			"dns_names":   i.DNSNames,
+			"uris":        i.URIs,
+			"spiffe_id":   i.SPIFFEID,
+		},
+	}
+}
@@ -0,0 +1,24 @@
+package authz
+
+const (
+	// DefaultRolesClaim is the default claim used to get the roles of the caller
+	DefaultRolesClaim = "roles"
+
+	// DefaultScopesClaim is the default claim used to get the scopes of the caller
+	DefaultScopesClaim = "scope"
+
+	// ErrorInfoDomain is the domain used in the error info details
+	ErrorInfoDomain = "authz"
+
+	// ReasonMissingClaims is the error info reason used when the token claims are not in the context
+	ReasonMissingClaims = "MISSING_CLAIMS"
+
+	// ReasonMissingRole is the error info reason used when the caller has none of the required roles
+	ReasonMissingRole = "MISSING_ROLE"
+
+	// ReasonMissingScope is the error info reason used when the caller lacks a required scope
+	ReasonMissingScope = "MISSING_SCOPE"
+
+	// ReasonClaimMismatch is the error info reason used when a claim is not equal to the expected value
+	ReasonClaimMismatch = "CLAIM_MISMATCH"
+)
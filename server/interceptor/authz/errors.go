@@ -0,0 +1,10 @@
+package authz
+
+import (
+	"errors"
+)
+
+var (
+	ErrNilPolicies      = errors.New("authorization policies cannot be nil")
+	ErrPermissionDenied = errors.New("permission denied")
+)
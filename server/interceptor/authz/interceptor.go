@@ -0,0 +1,230 @@
+package authz
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+
+	gojwtgrpc "github.com/ralvarezdev/go-jwt/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gogrpc "github.com/ralvarezdev/go-grpc"
+	gogrpcservercontext "github.com/ralvarezdev/go-grpc/server/context"
+)
+
+type (
+	// Interceptor is the interceptor for the authorization
+	Interceptor struct {
+		policies         *gogrpc.MethodMatcher[*Policy]
+		rolesClaim       string
+		scopesClaim      string
+		detailsGenerator gogrpc.ErrorInfoGenerator
+		logger           *slog.Logger
+	}
+)
+
+// NewInterceptor creates a new authorization interceptor
+//
+// Parameters:
+//
+//   - policies: the method matcher of the authorization policies
+//   - options: the options for the interceptor (optional, can be nil)
+//   - detailsGenerator: the error info generator (optional, can be nil)
+//   - logger: the logger to use (optional, can be nil)
+//
+// Returns:
+//
+//   - *Interceptor: the interceptor
+//   - error: if the policies map is nil
+func NewInterceptor(
+	policies *gogrpc.MethodMatcher[*Policy],
+	options *Options,
+	detailsGenerator gogrpc.ErrorInfoGenerator,
+	logger *slog.Logger,
+) (*Interceptor, error) {
+	// Check if the policies map is nil
+	if policies == nil {
+		return nil, ErrNilPolicies
+	}
+
+	// Set the default claims
+	rolesClaim := DefaultRolesClaim
+	scopesClaim := DefaultScopesClaim
+	if options != nil {
+		if options.RolesClaim != "" {
+			rolesClaim = options.RolesClaim
+		}
+		if options.ScopesClaim != "" {
+			scopesClaim = options.ScopesClaim
+		}
+	}
+
+	// Set the default error details generator
+	if detailsGenerator == nil {
+		detailsGenerator = gogrpc.NewDefaultErrorDetailsGenerator(logger)
+	}
+
+	if logger != nil {
+		logger = logger.With(
+			slog.String("grpc_server_interceptor", "authorization"),
+		)
+	}
+
+	return &Interceptor{
+		policies:         policies,
+		rolesClaim:       rolesClaim,
+		scopesClaim:      scopesClaim,
+		detailsGenerator: detailsGenerator,
+		logger:           logger,
+	}, nil
+}
+
+// permissionDenied creates a permission denied status error with an error info detail
+//
+// Parameters:
+//
+//   - fullMethod: the full method name of the request
+//   - reason: the reason of the denial
+//   - metadata: additional metadata of the denial
+//
+// Returns:
+//
+//   - error: the permission denied status error
+func (i Interceptor) permissionDenied(
+	fullMethod, reason string,
+	metadata map[string]string,
+) error {
+	if i.logger != nil {
+		i.logger.Debug(
+			"Permission denied",
+			slog.String("method", fullMethod),
+			slog.String("reason", reason),
+		)
+	}
+
+	// Add the method to the metadata
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+	metadata["method"] = fullMethod
+
+	st := status.New(codes.PermissionDenied, ErrPermissionDenied.Error())
+	stWithDetails, err := st.WithDetails(
+		i.detailsGenerator.NewErrorInfo(reason, ErrorInfoDomain, metadata),
+	)
+	if err != nil {
+		if i.logger != nil {
+			i.logger.Error(
+				"Failed to add error info to status",
+				slog.String("method", fullMethod),
+				slog.String("error", err.Error()),
+			)
+		}
+		return st.Err()
+	}
+	return stWithDetails.Err()
+}
+
+// authorize checks the policy of the given method against the token claims in the context
+//
+// Parameters:
+//
+//   - ctx: the context of the request
+//   - fullMethod: the full method name of the request
+//
+// Returns:
+//
+//   - error: a gRPC status error if the authorization failed
+func (i Interceptor) authorize(ctx context.Context, fullMethod string) error {
+	// Check if the method has a policy
+	policy, ok := i.policies.Match(fullMethod)
+	if !ok || policy == nil {
+		return nil
+	}
+
+	// Get the token claims from the context
+	claims, err := gojwtgrpc.GetCtxTokenClaims(ctx)
+	if err != nil {
+		return i.permissionDenied(fullMethod, ReasonMissingClaims, nil)
+	}
+
+	// Check if the caller has at least one of the required roles
+	if len(policy.Roles) > 0 {
+		roles := gogrpcservercontext.GetClaimValues(claims, i.rolesClaim)
+		if !slices.ContainsFunc(roles, func(role string) bool {
+			return slices.Contains(policy.Roles, role)
+		}) {
+			return i.permissionDenied(
+				fullMethod,
+				ReasonMissingRole,
+				map[string]string{"roles": strings.Join(policy.Roles, " ")},
+			)
+		}
+	}
+
+	// Check if the caller has all the required scopes
+	if len(policy.Scopes) > 0 {
+		scopes := gogrpcservercontext.GetClaimValues(claims, i.scopesClaim)
+		for _, scope := range policy.Scopes {
+			if !slices.Contains(scopes, scope) {
+				return i.permissionDenied(
+					fullMethod,
+					ReasonMissingScope,
+					map[string]string{"scope": scope},
+				)
+			}
+		}
+	}
+
+	// Check if the claims are equal to the expected values
+	for claim, expected := range policy.Claims {
+		value, found := claims[claim]
+		if !found || value == nil || gogrpcservercontext.FormatClaimValue(value) != expected {
+			return i.permissionDenied(
+				fullMethod,
+				ReasonClaimMismatch,
+				map[string]string{"claim": claim},
+			)
+		}
+	}
+	return nil
+}
+
+// Authorize returns the authorization interceptor
+//
+// Returns:
+//
+//   - grpc.UnaryServerInterceptor: the authorization interceptor
+func (i Interceptor) Authorize() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req any, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		// Authorize the request
+		if err := i.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AuthorizeStream returns the stream authorization interceptor
+//
+// Returns:
+//
+//   - grpc.StreamServerInterceptor: the stream authorization interceptor
+func (i Interceptor) AuthorizeStream() grpc.StreamServerInterceptor {
+	return func(
+		srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		// Authorize the stream
+		if err := i.authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,137 @@
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	gojwtgrpc "github.com/ralvarezdev/go-jwt/grpc"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gogrpc "github.com/ralvarezdev/go-grpc"
+)
+
+func TestAuthorize(t *testing.T) {
+	policies, err := gogrpc.NewMethodMatcher(
+		map[string]*Policy{
+			"/pkg.Service/Admin": {Roles: []string{"admin"}},
+			"/pkg.Service/Write": {Scopes: []string{"read", "write"}},
+			"/pkg.Service/Tenant": {
+				Claims: map[string]string{"tenant": "acme"},
+			},
+			"/pkg.Service/TenantID": {
+				Claims: map[string]string{"tenant_id": "1234567"},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("NewMethodMatcher: %v", err)
+	}
+	interceptor, err := NewInterceptor(policies, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("NewInterceptor: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		claims     jwt.MapClaims
+		wantReason string
+	}{
+		{
+			name:   "method without policy",
+			method: "/pkg.Service/Public",
+		},
+		{
+			name:       "missing claims",
+			method:     "/pkg.Service/Admin",
+			wantReason: ReasonMissingClaims,
+		},
+		{
+			name:   "allowed role",
+			method: "/pkg.Service/Admin",
+			claims: jwt.MapClaims{"roles": []any{"user", "admin"}},
+		},
+		{
+			name:       "missing role",
+			method:     "/pkg.Service/Admin",
+			claims:     jwt.MapClaims{"roles": []any{"user"}},
+			wantReason: ReasonMissingRole,
+		},
+		{
+			name:   "all scopes",
+			method: "/pkg.Service/Write",
+			claims: jwt.MapClaims{"scope": "read write"},
+		},
+		{
+			name:       "missing scope",
+			method:     "/pkg.Service/Write",
+			claims:     jwt.MapClaims{"scope": "read"},
+			wantReason: ReasonMissingScope,
+		},
+		{
+			name:       "claim mismatch",
+			method:     "/pkg.Service/Tenant",
+			claims:     jwt.MapClaims{"tenant": "other"},
+			wantReason: ReasonClaimMismatch,
+		},
+		{
+			name:   "numeric claim",
+			method: "/pkg.Service/TenantID",
+			claims: jwt.MapClaims{"tenant_id": float64(1234567)},
+		},
+		{
+			name:       "numeric claim mismatch",
+			method:     "/pkg.Service/TenantID",
+			claims:     jwt.MapClaims{"tenant_id": float64(7654321)},
+			wantReason: ReasonClaimMismatch,
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				ctx := context.Background()
+				if test.claims != nil {
+					ctx = gojwtgrpc.SetCtxTokenClaims(ctx, test.claims)
+				}
+
+				called := false
+				_, err := interceptor.Authorize()(
+					ctx,
+					nil,
+					&grpc.UnaryServerInfo{FullMethod: test.method},
+					func(context.Context, any) (any, error) {
+						called = true
+						return nil, nil
+					},
+				)
+				if test.wantReason == "" {
+					if err != nil || !called {
+						t.Fatalf("expected the handler to be called, got error %v", err)
+					}
+					return
+				}
+				if called {
+					t.Fatal("expected the handler not to be called")
+				}
+
+				st := status.Convert(err)
+				if st.Code() != codes.PermissionDenied {
+					t.Fatalf("expected PermissionDenied, got %v", st.Code())
+				}
+				for _, detail := range st.Details() {
+					if errorInfo, ok := detail.(*errdetails.ErrorInfo); ok {
+						if errorInfo.GetReason() != test.wantReason {
+							t.Fatalf("expected reason %q, got %q", test.wantReason, errorInfo.GetReason())
+						}
+						return
+					}
+				}
+				t.Fatal("expected an error info detail")
+			},
+		)
+	}
+}
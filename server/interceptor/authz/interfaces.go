@@ -0,0 +1,13 @@
+package authz
+
+import (
+	"google.golang.org/grpc"
+)
+
+type (
+	// Authorization interface
+	Authorization interface {
+		Authorize() grpc.UnaryServerInterceptor
+		AuthorizeStream() grpc.StreamServerInterceptor
+	}
+)
@@ -0,0 +1,24 @@
+package authz
+
+type (
+	// Policy is the authorization policy of a method
+	Policy struct {
+		// Roles are the roles allowed to call the method, the caller must have at least one of them
+		Roles []string
+
+		// Scopes are the scopes required to call the method, the caller must have all of them
+		Scopes []string
+
+		// Claims are the claims that must be equal to the given values
+		Claims map[string]string
+	}
+
+	// Options are the options for the authorization interceptor
+	Options struct {
+		// RolesClaim is the claim used to get the roles of the caller
+		RolesClaim string
+
+		// ScopesClaim is the claim used to get the scopes of the caller
+		ScopesClaim string
+	}
+)
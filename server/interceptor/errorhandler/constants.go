@@ -0,0 +1,6 @@
+package errorhandler
+
+const (
+	// ErrorIDLength is the number of random bytes of the error IDs, which are hex encoded
+	ErrorIDLength = 16
+)
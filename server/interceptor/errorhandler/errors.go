@@ -0,0 +1,15 @@
+package errorhandler
+
+import (
+	"errors"
+)
+
+var (
+	ErrNilRegistry = errors.New("error registry cannot be nil")
+	ErrNilTarget   = errors.New("error target cannot be nil")
+	ErrNilMapping  = errors.New("error mapping cannot be nil")
+
+	// ErrReportDropped is wrapped by the errors of the reporters that drop a report on purpose, e.g. when their queue
+	// is full, so they are not logged as failures
+	ErrReportDropped = errors.New("report dropped")
+)
@@ -2,22 +2,38 @@ package errorhandler
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"log/slog"
 	"runtime/debug"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
 	goflagsmode	"github.com/ralvarezdev/go-flags/mode"
 	goflags "github.com/ralvarezdev/go-flags"
 
 	gogrpc "github.com/ralvarezdev/go-grpc"
+	gogrpcservercontext "github.com/ralvarezdev/go-grpc/server/context"
 )
 
 type (
 	// Interceptor is the interceptor for the error handler
 	Interceptor struct {
 		modeFlag *goflagsmode.Flag
+		registry *Registry
+		detailsGenerator gogrpc.DebugDetailsGenerator
+		reporter Reporter
+		timeouts *atomic.Uint64
+		cancellations *atomic.Uint64
+		serverTimeouts *atomic.Uint64
 		logger *slog.Logger
 	}
 )
@@ -27,17 +43,31 @@ type (
 // Parameters:
 //
 //   - modeFlag: the application mode flag
+//   - registry: the registry of the errors mapped to gRPC statuses (can be nil)
+//   - detailsGenerator: the error details generator (can be nil)
+//   - reporter: the reporter of the recovered panics and the internal errors (can be nil)
 //   - logger: the logger to use (can be nil)
 //
 // Returns:
 //
 //  - *Interceptor: the interceptor
 //  - error: if there was an error creating the interceptor
-func NewInterceptor(modeFlag *goflagsmode.Flag, logger *slog.Logger) (*Interceptor, error) {
+func NewInterceptor(
+	modeFlag *goflagsmode.Flag,
+	registry *Registry,
+	detailsGenerator gogrpc.DebugDetailsGenerator,
+	reporter Reporter,
+	logger *slog.Logger,
+) (*Interceptor, error) {
 	// Check if the mode flag is nil
 	if modeFlag == nil {
 		return nil, goflags.ErrNilFlag
 	}
+
+	// Set the default error details generator
+	if detailsGenerator == nil {
+		detailsGenerator = gogrpc.NewDefaultErrorDetailsGenerator(logger)
+	}
 	
 	// Create the logger for the interceptor
 	if logger != nil {
@@ -48,10 +78,344 @@ func NewInterceptor(modeFlag *goflagsmode.Flag, logger *slog.Logger) (*Intercept
 
 	return &Interceptor{
 		modeFlag: modeFlag,
+		registry: registry,
+		detailsGenerator: detailsGenerator,
+		reporter: reporter,
+		timeouts: &atomic.Uint64{},
+		cancellations: &atomic.Uint64{},
+		serverTimeouts: &atomic.Uint64{},
 		logger: logger,
 	}, nil
 }
 
+// newErrorID generates a new error ID, used to correlate the internal errors returned to the clients with the logs
+//
+// Returns:
+//
+//   - string: the error ID
+func newErrorID() string {
+	id := make([]byte, ErrorIDLength)
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(id)
+}
+
+// newStatusError creates a new gRPC status error with the error ID and, if not in production mode, the stack trace
+// as details
+//
+// Parameters:
+//
+//   - code: the code of the status
+//   - message: the message of the status
+//   - errorID: the error ID
+//   - stack: the stack trace (optional, can be nil)
+//   - detail: the debugging detail sent along the stack trace
+//
+// Returns:
+//
+//   - error: the gRPC status error
+func (i Interceptor) newStatusError(
+	code codes.Code,
+	message, errorID string,
+	stack []byte,
+	detail string,
+) error {
+	st := status.New(code, message)
+	details := []protoadapt.MessageV1{
+		i.detailsGenerator.NewRequestInfo(errorID, ""),
+	}
+	if stack != nil && !i.modeFlag.IsProd() {
+		details = append(
+			details, i.detailsGenerator.NewDebugInfo(
+				strings.Split(strings.TrimSpace(string(stack)), "\n"),
+				detail,
+			),
+		)
+	}
+	stWithDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+	return stWithDetails.Err()
+}
+
+// cloneRequest clones the request or the stream message of a report, so the reporters that handle it after the RPC
+// returns do not race with the handler or the stream reusing it
+//
+// Parameters:
+//
+//   - req: the request or the stream message, if any
+//
+// Returns:
+//
+//   - any: the cloned protobuf message, or the given value if it is not a protobuf message
+func cloneRequest(req any) any {
+	if message, ok := req.(proto.Message); ok {
+		return proto.Clone(message)
+	}
+	return req
+}
+
+// report reports a recovered panic or an internal error to the reporter, if any
+//
+// Parameters:
+//
+//   - ctx: the context of the request
+//   - report: the report
+func (i Interceptor) report(ctx context.Context, report *Report) {
+	if i.reporter == nil {
+		return
+	}
+
+	// Clone the request, since it may be reported after the RPC returns
+	report.Request = cloneRequest(report.Request)
+
+	// Set the principal of the request, if any
+	if principal, err := gogrpcservercontext.GetPrincipal(ctx); err == nil {
+		report.Principal = principal
+	}
+
+	err := i.reporter.Report(ctx, report)
+	if err == nil || i.logger == nil {
+		return
+	}
+
+	// Do not log the dropped reports as failures, since they come in bursts, e.g. during a panic storm, and the
+	// reporters count them
+	if errors.Is(err, ErrReportDropped) {
+		i.logger.Debug(
+			"Dropped error report",
+			slog.String("method", report.Method),
+			slog.String("error_id", report.ErrorID),
+		)
+		return
+	}
+	i.logger.Error(
+		"Failed to report error",
+		slog.String("method", report.Method),
+		slog.String("error_id", report.ErrorID),
+		slog.String("error", err.Error()),
+	)
+}
+
+// mapError maps an error returned by a handler that is not a gRPC status error
+//
+// Parameters:
+//
+//   - ctx: the context of the request
+//   - fullMethod: the full method name of the request
+//   - req: the request, if any
+//   - err: the error returned by the handler
+//
+// Returns:
+//
+//   - error: the gRPC status error
+func (i Interceptor) mapError(
+	ctx context.Context,
+	fullMethod string,
+	req any,
+	err error,
+) error {
+	// Check if the error is already a gRPC status error
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	// Check if the error is mapped
+	if st, ok := i.registry.Map(err); ok {
+		return st.Err()
+	}
+
+	// Normalize the context errors
+	if contextErr, ok := i.normalizeContextError(err); ok {
+		return contextErr
+	}
+
+	// Log the unmapped error
+	errorID := newErrorID()
+	if i.logger != nil {
+		i.logger.Error(
+			"Unmapped error returned by handler",
+			slog.String("method", fullMethod),
+			slog.String("error_id", errorID),
+			slog.String("error", err.Error()),
+		)
+	}
+	i.report(
+		ctx, &Report{
+			ErrorID: errorID,
+			Method:  fullMethod,
+			Request: req,
+			Error:   err.Error(),
+			Time:    time.Now(),
+		},
+	)
+
+	// Hide the unmapped error in production mode
+	if i.modeFlag.IsProd() {
+		return i.newStatusError(
+			codes.Internal,
+			gogrpc.InternalServerError,
+			errorID,
+			nil,
+			"",
+		)
+	}
+	return i.newStatusError(codes.Unknown, err.Error(), errorID, nil, "")
+}
+
+// handlePanic logs a recovered panic and converts it to a gRPC status error
+//
+// Parameters:
+//
+//   - ctx: the context of the request
+//   - fullMethod: the full method name of the request
+//   - req: the request or the stream message, if any
+//   - r: the recovered value
+//
+// Returns:
+//
+//   - error: the internal gRPC status error with the error ID, and with the panic message and the stack trace if not
+//     in production mode
+func (i Interceptor) handlePanic(
+	ctx context.Context,
+	fullMethod string,
+	req any,
+	r any,
+) error {
+	// Log the panic
+	stack := debug.Stack()
+	errorID := newErrorID()
+	if i.logger != nil {
+		i.logger.Error(
+			"Panic recovered",
+			slog.Any("method", fullMethod),
+			slog.String("error_id", errorID),
+			slog.Any("error", r),
+			slog.String("stack_trace", string(stack)),
+		)
+	}
+	i.report(
+		ctx, &Report{
+			ErrorID: errorID,
+			Method:  fullMethod,
+			Request: req,
+			Error:   fmt.Sprint(r),
+			Panic:   true,
+			Stack:   string(stack),
+			Time:    time.Now(),
+		},
+	)
+
+	// Check if we are in production mode
+	if i.modeFlag.IsProd() {
+		// Set the error to internal server error
+		return i.newStatusError(
+			codes.Internal,
+			gogrpc.InternalServerError,
+			errorID,
+			nil,
+			"",
+		)
+	}
+
+	// Set the error to the panic message, with the stack trace as debug info
+	panicMessage := fmt.Sprintf("Panic: %v", r)
+	return i.newStatusError(
+		codes.Internal,
+		panicMessage,
+		errorID,
+		stack,
+		panicMessage,
+	)
+}
+
+// normalizeContextError converts the context deadline exceeded and canceled errors, even if wrapped, to their gRPC
+// status errors
+//
+// Parameters:
+//
+//   - err: the error returned by the handler
+//
+// Returns:
+//
+//   - error: the gRPC status error
+//   - bool: true if the error is a context error, false otherwise
+func (i Interceptor) normalizeContextError(err error) (error, bool) {
+	var code codes.Code
+	var sentinel error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		code, sentinel = codes.DeadlineExceeded, context.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		code, sentinel = codes.Canceled, context.Canceled
+	default:
+		return nil, false
+	}
+
+	// Hide the wrapping messages in production mode
+	if i.modeFlag.IsProd() {
+		return status.Error(code, sentinel.Error()), true
+	}
+	return status.Error(code, err.Error()), true
+}
+
+// checkAbandoned logs and counts the RPCs that finished after the client gave up, either because their deadline
+// exceeded while the server was still handling them or because the client canceled them, and the RPCs whose handler
+// timed out on its own while the RPC was still live
+//
+// Parameters:
+//
+//   - ctx: the context of the request
+//   - fullMethod: the full method name of the request
+//   - startedAt: the time at which the server started handling the request
+//   - err: the gRPC status error returned to the client, if any
+func (i Interceptor) checkAbandoned(
+	ctx context.Context,
+	fullMethod string,
+	startedAt time.Time,
+	err error,
+) {
+	// Check if the deadline exceeded or the client canceled the RPC, or else if the handler timed out on its own
+	var message string
+	switch ctxErr := ctx.Err(); {
+	case errors.Is(ctxErr, context.DeadlineExceeded):
+		i.timeouts.Add(1)
+		message = "RPC finished after its deadline exceeded"
+	case ctxErr != nil:
+		i.cancellations.Add(1)
+		message = "RPC finished after the client canceled it"
+	case status.Code(err) == codes.DeadlineExceeded:
+		i.serverTimeouts.Add(1)
+		message = "RPC handler timed out before the RPC deadline"
+	default:
+		return
+	}
+
+	if i.logger != nil {
+		i.logger.Warn(
+			message,
+			slog.String("method", fullMethod),
+			slog.Duration("duration", time.Since(startedAt)),
+		)
+	}
+}
+
+// GetAbandonedRPCStats returns the number of RPCs that finished after the client gave up, and the number of RPCs
+// whose handler timed out on its own
+//
+// Returns:
+//
+//   - AbandonedRPCStats: the abandoned RPCs stats
+func (i Interceptor) GetAbandonedRPCStats() AbandonedRPCStats {
+	return AbandonedRPCStats{
+		Timeouts:       i.timeouts.Load(),
+		Cancellations:  i.cancellations.Load(),
+		ServerTimeouts: i.serverTimeouts.Load(),
+	}
+}
+
 // HandleError returns the error handler interceptor
 //
 // Returns:
@@ -64,32 +428,45 @@ func (i Interceptor) HandleError() grpc.UnaryServerInterceptor {
 	) (value any, err error) {
 		defer func() {
 			if r := recover(); r != nil {
-				// Log the panic
-				stack := debug.Stack()
-				if i.logger != nil {
-					i.logger.Error(
-						"Panic recovered",
-						slog.Any("method", info.FullMethod),
-						slog.Any("error", r),
-						slog.String("stack_trace", string(stack)),
-					)
-				}
-
-				// Check if we are in production mode
-				if i.modeFlag.IsProd() {
-					// Set the error to internal server error
-					err = status.Error(codes.Internal, gogrpc.InternalServerError)
-				} else {
-					// Set the error to the panic message
-					err = status.Errorf(
-						codes.Internal,
-						"Panic: %v\nStack Trace:\n%s",
-						r,
-						string(stack),
-					)
-				}
+				err = i.handlePanic(ctx, info.FullMethod, req, r)
+			}
+		}()
+
+		// Map the error returned by the handler
+		startedAt := time.Now()
+		value, err = handler(ctx, req)
+		if err != nil {
+			err = i.mapError(ctx, info.FullMethod, req, err)
+		}
+		i.checkAbandoned(ctx, info.FullMethod, startedAt, err)
+		return value, err
+	}
+}
+
+// HandleStreamError returns the stream error handler interceptor, which also recovers the panics in the RecvMsg and
+// SendMsg calls of the stream
+//
+// Returns:
+//
+//   - grpc.StreamServerInterceptor: the stream error handler interceptor
+func (i Interceptor) HandleStreamError() grpc.StreamServerInterceptor {
+	return func(
+		srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = i.handlePanic(ss.Context(), info.FullMethod, nil, r)
 			}
 		}()
-		return handler(ctx, req)
+
+		// Map the error returned by the handler
+		startedAt := time.Now()
+		err = handler(srv, newRecoveringServerStream(ss, i, info.FullMethod))
+		if err != nil {
+			err = i.mapError(ss.Context(), info.FullMethod, nil, err)
+		}
+		i.checkAbandoned(ss.Context(), info.FullMethod, startedAt, err)
+		return err
 	}
 }
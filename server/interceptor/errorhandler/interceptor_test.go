@@ -0,0 +1,386 @@
+package errorhandler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	goflagsmode "github.com/ralvarezdev/go-flags/mode"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	gogrpc "github.com/ralvarezdev/go-grpc"
+	gogrpcservercontext "github.com/ralvarezdev/go-grpc/server/context"
+)
+
+const (
+	testMethod = "/pkg.Service/Method"
+)
+
+type (
+	// stubReporter records the reports and returns the given error
+	stubReporter struct {
+		reports []*Report
+		err     error
+	}
+
+	// messagesHandler records the levels of the logged messages
+	messagesHandler struct {
+		messages map[string]slog.Level
+	}
+)
+
+func (s *stubReporter) Report(_ context.Context, report *Report) error {
+	s.reports = append(s.reports, report)
+	return s.err
+}
+
+func (messagesHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h messagesHandler) Handle(_ context.Context, record slog.Record) error {
+	h.messages[record.Message] = record.Level
+	return nil
+}
+
+func (h messagesHandler) WithAttrs([]slog.Attr) slog.Handler {
+	return h
+}
+
+func (h messagesHandler) WithGroup(string) slog.Handler {
+	return h
+}
+
+// newTestInterceptor creates an error handler interceptor in the given mode
+func newTestInterceptor(t *testing.T, mode goflagsmode.Mode, reporter Reporter) *Interceptor {
+	t.Helper()
+
+	interceptor, err := NewInterceptor(
+		goflagsmode.NewFlag(mode, goflagsmode.AllowedModes),
+		nil,
+		nil,
+		reporter,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("NewInterceptor: %v", err)
+	}
+	return interceptor
+}
+
+// handle calls the error handler with the given handler
+func handle(
+	ctx context.Context,
+	interceptor *Interceptor,
+	req any,
+	handler grpc.UnaryHandler,
+) error {
+	_, err := interceptor.HandleError()(
+		ctx,
+		req,
+		&grpc.UnaryServerInfo{FullMethod: testMethod},
+		handler,
+	)
+	return err
+}
+
+// getDetails gets the request info and the debug info details of a gRPC status error
+func getDetails(err error) (*errdetails.RequestInfo, *errdetails.DebugInfo) {
+	var requestInfo *errdetails.RequestInfo
+	var debugInfo *errdetails.DebugInfo
+	for _, detail := range status.Convert(err).Details() {
+		switch detail := detail.(type) {
+		case *errdetails.RequestInfo:
+			requestInfo = detail
+		case *errdetails.DebugInfo:
+			debugInfo = detail
+		}
+	}
+	return requestInfo, debugInfo
+}
+
+func TestHandleErrorInternalErrors(t *testing.T) {
+	panicHandler := func(context.Context, any) (any, error) {
+		panic("boom")
+	}
+	errorHandler := func(context.Context, any) (any, error) {
+		return nil, errors.New("database is down")
+	}
+
+	tests := []struct {
+		name            string
+		mode            goflagsmode.Mode
+		handler         grpc.UnaryHandler
+		expectedCode    codes.Code
+		expectedMessage string
+		expectedStack   bool
+	}{
+		{
+			name:            "panic in production mode",
+			mode:            goflagsmode.Prod,
+			handler:         panicHandler,
+			expectedCode:    codes.Internal,
+			expectedMessage: gogrpc.InternalServerError,
+		},
+		{
+			name:            "panic in development mode",
+			mode:            goflagsmode.Dev,
+			handler:         panicHandler,
+			expectedCode:    codes.Internal,
+			expectedMessage: "Panic: boom",
+			expectedStack:   true,
+		},
+		{
+			name:            "unmapped error in production mode",
+			mode:            goflagsmode.Prod,
+			handler:         errorHandler,
+			expectedCode:    codes.Internal,
+			expectedMessage: gogrpc.InternalServerError,
+		},
+		{
+			name:            "unmapped error in development mode",
+			mode:            goflagsmode.Dev,
+			handler:         errorHandler,
+			expectedCode:    codes.Unknown,
+			expectedMessage: "database is down",
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				err := handle(
+					context.Background(),
+					newTestInterceptor(t, test.mode, nil),
+					nil,
+					test.handler,
+				)
+				st := status.Convert(err)
+				if st.Code() != test.expectedCode || st.Message() != test.expectedMessage {
+					t.Fatalf(
+						"expected %v %q, got %v %q",
+						test.expectedCode,
+						test.expectedMessage,
+						st.Code(),
+						st.Message(),
+					)
+				}
+
+				// The error ID is returned as request info, and the stack trace as debug info outside production mode
+				requestInfo, debugInfo := getDetails(err)
+				if requestInfo == nil || len(requestInfo.GetRequestId()) != 2*ErrorIDLength {
+					t.Fatalf("expected the error ID as request info, got %v", requestInfo)
+				}
+				if test.expectedStack != (debugInfo != nil && len(debugInfo.GetStackEntries()) > 0) {
+					t.Fatalf("expected the stack trace as debug info to be %v, got %v", test.expectedStack, debugInfo)
+				}
+			},
+		)
+	}
+}
+
+func TestHandleErrorStatusErrorsPassThrough(t *testing.T) {
+	expected := status.Error(codes.NotFound, "not found")
+	err := handle(
+		context.Background(),
+		newTestInterceptor(t, goflagsmode.Prod, nil),
+		nil,
+		func(context.Context, any) (any, error) {
+			return nil, expected
+		},
+	)
+	if !errors.Is(err, expected) {
+		t.Fatalf("expected the status error to pass through, got %v", err)
+	}
+}
+
+func TestHandleErrorReport(t *testing.T) {
+	reporter := &stubReporter{}
+	interceptor := newTestInterceptor(t, goflagsmode.Prod, reporter)
+	ctx := gogrpcservercontext.SetCtxPrincipal(
+		context.Background(),
+		&gogrpcservercontext.Principal{Subject: "user"},
+	)
+	req := wrapperspb.String("original")
+
+	err := handle(
+		ctx,
+		interceptor,
+		req,
+		func(context.Context, any) (any, error) {
+			panic("boom")
+		},
+	)
+	requestInfo, _ := getDetails(err)
+	if requestInfo == nil {
+		t.Fatal("expected the error ID as request info")
+	}
+
+	// The handler reusing the request after the RPC returns does not change the report
+	req.Value = "reused"
+
+	if len(reporter.reports) != 1 {
+		t.Fatalf("expected a single report, got %d", len(reporter.reports))
+	}
+	report := reporter.reports[0]
+	if report.ErrorID != requestInfo.GetRequestId() || report.Method != testMethod || !report.Panic ||
+		report.Error != "boom" || report.Stack == "" {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if report.Principal == nil || report.Principal.Subject != "user" {
+		t.Fatalf("expected the principal to be reported, got %+v", report.Principal)
+	}
+	reported, ok := report.Request.(*wrapperspb.StringValue)
+	if !ok || reported == req || reported.GetValue() != "original" {
+		t.Fatalf("expected a copy of the request to be reported, got %v", report.Request)
+	}
+}
+
+func TestHandleErrorReportFailures(t *testing.T) {
+	tests := []struct {
+		name            string
+		err             error
+		expectedMessage string
+		expectedLevel   slog.Level
+		absentMessage   string
+	}{
+		{
+			name:            "dropped report",
+			err:             fmt.Errorf("queue is full: %w", ErrReportDropped),
+			expectedMessage: "Dropped error report",
+			expectedLevel:   slog.LevelDebug,
+			absentMessage:   "Failed to report error",
+		},
+		{
+			name:            "failed report",
+			err:             errors.New("connection refused"),
+			expectedMessage: "Failed to report error",
+			expectedLevel:   slog.LevelError,
+			absentMessage:   "Dropped error report",
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				handler := messagesHandler{messages: make(map[string]slog.Level)}
+				interceptor, err := NewInterceptor(
+					goflagsmode.NewFlag(goflagsmode.Prod, goflagsmode.AllowedModes),
+					nil,
+					nil,
+					&stubReporter{err: test.err},
+					slog.New(handler),
+				)
+				if err != nil {
+					t.Fatalf("NewInterceptor: %v", err)
+				}
+
+				_ = handle(
+					context.Background(),
+					interceptor,
+					nil,
+					func(context.Context, any) (any, error) {
+						panic("boom")
+					},
+				)
+				if level, ok := handler.messages[test.expectedMessage]; !ok || level != test.expectedLevel {
+					t.Fatalf("expected %q at %v, got %v", test.expectedMessage, test.expectedLevel, handler.messages)
+				}
+				if _, ok := handler.messages[test.absentMessage]; ok {
+					t.Fatalf("expected no %q log, got %v", test.absentMessage, handler.messages)
+				}
+			},
+		)
+	}
+}
+
+func TestHandleErrorContextErrors(t *testing.T) {
+	expiredCtx := func() (context.Context, context.CancelFunc) {
+		return context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	}
+	canceledCtx := func() (context.Context, context.CancelFunc) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		return ctx, cancel
+	}
+	liveCtx := func() (context.Context, context.CancelFunc) {
+		return context.WithCancel(context.Background())
+	}
+
+	tests := []struct {
+		name         string
+		ctx          func() (context.Context, context.CancelFunc)
+		err          error
+		expectedCode codes.Code
+		expected     AbandonedRPCStats
+	}{
+		{
+			name:         "RPC deadline exceeded",
+			ctx:          expiredCtx,
+			err:          fmt.Errorf("query: %w", context.DeadlineExceeded),
+			expectedCode: codes.DeadlineExceeded,
+			expected:     AbandonedRPCStats{Timeouts: 1},
+		},
+		{
+			name:         "RPC canceled by the client",
+			ctx:          canceledCtx,
+			err:          fmt.Errorf("query: %w", context.Canceled),
+			expectedCode: codes.Canceled,
+			expected:     AbandonedRPCStats{Cancellations: 1},
+		},
+		{
+			name:         "handler timed out while the RPC is live",
+			ctx:          liveCtx,
+			err:          fmt.Errorf("query: %w", context.DeadlineExceeded),
+			expectedCode: codes.DeadlineExceeded,
+			expected:     AbandonedRPCStats{ServerTimeouts: 1},
+		},
+		{
+			name:         "downstream call timed out while the RPC is live",
+			ctx:          liveCtx,
+			err:          status.Error(codes.DeadlineExceeded, "downstream"),
+			expectedCode: codes.DeadlineExceeded,
+			expected:     AbandonedRPCStats{ServerTimeouts: 1},
+		},
+		{
+			name:         "handler canceled while the RPC is live",
+			ctx:          liveCtx,
+			err:          fmt.Errorf("query: %w", context.Canceled),
+			expectedCode: codes.Canceled,
+		},
+		{
+			name:         "handler succeeded while the RPC is live",
+			ctx:          liveCtx,
+			expectedCode: codes.OK,
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				ctx, cancel := test.ctx()
+				defer cancel()
+
+				interceptor := newTestInterceptor(t, goflagsmode.Prod, nil)
+				err := handle(
+					ctx,
+					interceptor,
+					nil,
+					func(context.Context, any) (any, error) {
+						return nil, test.err
+					},
+				)
+				if status.Code(err) != test.expectedCode {
+					t.Fatalf("expected %v, got %v", test.expectedCode, err)
+				}
+				if stats := interceptor.GetAbandonedRPCStats(); stats != test.expected {
+					t.Fatalf("expected the stats %+v, got %+v", test.expected, stats)
+				}
+			},
+		)
+	}
+}
@@ -1,6 +1,8 @@
 package errorhandler
 
 import (
+	"context"
+
 	"google.golang.org/grpc"
 )
 
@@ -8,5 +10,19 @@ type (
 	// ErrorHandler interface
 	ErrorHandler interface {
 		HandleError() grpc.UnaryServerInterceptor
+		HandleStreamError() grpc.StreamServerInterceptor
+		GetAbandonedRPCStats() AbandonedRPCStats
+	}
+
+	// Reporter is the interface for the sinks of the recovered panics and the internal errors, e.g. the
+	// crash-reporting backends
+	Reporter interface {
+		Report(ctx context.Context, report *Report) error
+	}
+
+	// BatchReporter is the interface for the reporters that can report several reports at once
+	BatchReporter interface {
+		Reporter
+		ReportBatch(ctx context.Context, reports []*Report) error
 	}
 )
@@ -0,0 +1,147 @@
+package errorhandler
+
+import (
+	"errors"
+	"sync"
+
+	"google.golang.org/grpc/status"
+)
+
+type (
+	// Registry is the registry that maps the sentinel errors and the error types to gRPC statuses
+	Registry struct {
+		mutex   sync.RWMutex
+		entries []registryEntry
+	}
+)
+
+// NewRegistry creates a new error registry
+//
+// Returns:
+//
+//   - *Registry: the registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// add adds an entry to the registry
+//
+// Parameters:
+//
+//   - matches: the function that checks if an error matches the entry
+//   - mapping: the mapping of the matched errors
+func (r *Registry) add(matches func(err error) bool, mapping *Mapping) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.entries = append(
+		r.entries, registryEntry{
+			matches: matches,
+			mapping: mapping,
+		},
+	)
+}
+
+// Register maps the errors that match the given sentinel error with errors.Is. The registrations are matched in
+// the order they were made
+//
+// Parameters:
+//
+//   - target: the sentinel error
+//   - mapping: the mapping of the matched errors
+//
+// Returns:
+//
+//   - error: if the target or the mapping are nil
+func (r *Registry) Register(target error, mapping *Mapping) error {
+	if r == nil {
+		return ErrNilRegistry
+	}
+	if target == nil {
+		return ErrNilTarget
+	}
+	if mapping == nil {
+		return ErrNilMapping
+	}
+	r.add(
+		func(err error) bool {
+			return errors.Is(err, target)
+		}, mapping,
+	)
+	return nil
+}
+
+// RegisterType maps the errors that match the given error type with errors.As. The registrations are matched in
+// the order they were made
+//
+// Parameters:
+//
+//   - r: the registry
+//   - mapping: the mapping of the matched errors
+//
+// Returns:
+//
+//   - error: if the registry or the mapping are nil
+func RegisterType[T error](r *Registry, mapping *Mapping) error {
+	if r == nil {
+		return ErrNilRegistry
+	}
+	if mapping == nil {
+		return ErrNilMapping
+	}
+	r.add(
+		func(err error) bool {
+			var target T
+			return errors.As(err, &target)
+		}, mapping,
+	)
+	return nil
+}
+
+// Map maps the given error to the gRPC status of the first matching registration
+//
+// Parameters:
+//
+//   - err: the error to map
+//
+// Returns:
+//
+//   - *status.Status: the mapped status, or nil if the error is not mapped
+//   - bool: true if the error is mapped, false otherwise
+func (r *Registry) Map(err error) (*status.Status, bool) {
+	if r == nil || err == nil {
+		return nil, false
+	}
+
+	// Get the mapping of the first matching registration
+	var mapping *Mapping
+	r.mutex.RLock()
+	for _, entry := range r.entries {
+		if entry.matches(err) {
+			mapping = entry.mapping
+			break
+		}
+	}
+	r.mutex.RUnlock()
+	if mapping == nil {
+		return nil, false
+	}
+
+	// Create the status
+	message := mapping.Message
+	if message == "" {
+		message = err.Error()
+	}
+	st := status.New(mapping.Code, message)
+	if mapping.Details == nil {
+		return st, true
+	}
+	details := mapping.Details(err)
+	if len(details) == 0 {
+		return st, true
+	}
+	stWithDetails, detailsErr := st.WithDetails(details...)
+	if detailsErr != nil {
+		return st, true
+	}
+	return stWithDetails, true
+}
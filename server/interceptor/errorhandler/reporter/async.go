@@ -0,0 +1,234 @@
+package reporter
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	gogrpcerrorhandler "github.com/ralvarezdev/go-grpc/server/interceptor/errorhandler"
+)
+
+type (
+	// AsyncReporter is the reporter wrapper that queues the reports in a bounded queue and sends them in batches
+	// from a background goroutine, so reporting never blocks the RPCs
+	AsyncReporter struct {
+		reporter      gogrpcerrorhandler.Reporter
+		queue         chan *gogrpcerrorhandler.Report
+		batchSize     int
+		flushInterval time.Duration
+		reportTimeout time.Duration
+		dropped       atomic.Uint64
+		mutex         sync.RWMutex
+		isClosed      bool
+		closed        chan struct{}
+		done          chan struct{}
+		logger        *slog.Logger
+	}
+)
+
+// NewAsyncReporter creates a new async batching reporter and starts its background goroutine
+//
+// Parameters:
+//
+//   - reporter: the wrapped reporter, which receives the batches at once if it is a BatchReporter
+//   - options: the options for the async reporter (optional, can be nil)
+//   - logger: the logger to use (optional, can be nil)
+//
+// Returns:
+//
+//   - *AsyncReporter: the async reporter
+//   - error: if the wrapped reporter is nil
+func NewAsyncReporter(
+	reporter gogrpcerrorhandler.Reporter,
+	options *Options,
+	logger *slog.Logger,
+) (*AsyncReporter, error) {
+	// Check if the wrapped reporter is nil
+	if reporter == nil {
+		return nil, ErrNilReporter
+	}
+
+	// Set the default options
+	queueSize := DefaultQueueSize
+	batchSize := DefaultBatchSize
+	flushInterval := DefaultFlushInterval
+	reportTimeout := DefaultReportTimeout
+	if options != nil {
+		if options.QueueSize > 0 {
+			queueSize = options.QueueSize
+		}
+		if options.BatchSize > 0 {
+			batchSize = options.BatchSize
+		}
+		if options.FlushInterval > 0 {
+			flushInterval = options.FlushInterval
+		}
+		if options.ReportTimeout > 0 {
+			reportTimeout = options.ReportTimeout
+		}
+	}
+
+	if logger != nil {
+		logger = logger.With(
+			slog.String("error_reporter", "async"),
+		)
+	}
+
+	a := &AsyncReporter{
+		reporter:      reporter,
+		queue:         make(chan *gogrpcerrorhandler.Report, queueSize),
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		reportTimeout: reportTimeout,
+		closed:        make(chan struct{}),
+		done:          make(chan struct{}),
+		logger:        logger,
+	}
+	go a.run()
+	return a, nil
+}
+
+// Report queues the given report without blocking
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - report: the report
+//
+// Returns:
+//
+//   - error: if the report is nil, the reporter is closed or the queue is full
+func (a *AsyncReporter) Report(
+	ctx context.Context,
+	report *gogrpcerrorhandler.Report,
+) error {
+	if report == nil {
+		return ErrNilReport
+	}
+
+	// Check if the reporter is closed, holding the lock while queueing so the report cannot be queued after the
+	// queue is drained
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	if a.isClosed {
+		return ErrReporterClosed
+	}
+
+	// Queue the report, dropping it if the queue is full
+	select {
+	case a.queue <- report:
+		return nil
+	default:
+		a.dropped.Add(1)
+		return ErrQueueFull
+	}
+}
+
+// Dropped returns the number of reports dropped because the queue was full
+//
+// Returns:
+//
+//   - uint64: the number of dropped reports
+func (a *AsyncReporter) Dropped() uint64 {
+	return a.dropped.Load()
+}
+
+// flush sends the given batch to the wrapped reporter
+//
+// Parameters:
+//
+//   - batch: the batch of reports
+func (a *AsyncReporter) flush(batch []*gogrpcerrorhandler.Report) {
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.reportTimeout)
+	defer cancel()
+
+	// Send the batch at once if the wrapped reporter supports it
+	if batchReporter, ok := a.reporter.(gogrpcerrorhandler.BatchReporter); ok {
+		if err := batchReporter.ReportBatch(ctx, batch); err != nil && a.logger != nil {
+			a.logger.Error(
+				"Failed to send reports batch",
+				slog.Int("reports", len(batch)),
+				slog.String("error", err.Error()),
+			)
+		}
+		return
+	}
+	for _, report := range batch {
+		if err := a.reporter.Report(ctx, report); err != nil && a.logger != nil {
+			a.logger.Error(
+				"Failed to send report",
+				slog.String("error_id", report.ErrorID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
+
+// run sends the queued reports in batches until the reporter is closed
+func (a *AsyncReporter) run() {
+	defer close(a.done)
+
+	ticker := time.NewTicker(a.flushInterval)
+	defer ticker.Stop()
+
+	var batch []*gogrpcerrorhandler.Report
+	for {
+		select {
+		case report := <-a.queue:
+			batch = append(batch, report)
+			if len(batch) >= a.batchSize {
+				a.flush(batch)
+				batch = nil
+			}
+		case <-ticker.C:
+			a.flush(batch)
+			batch = nil
+		case <-a.closed:
+			// Drain the queue before exiting
+			for {
+				select {
+				case report := <-a.queue:
+					batch = append(batch, report)
+					if len(batch) >= a.batchSize {
+						a.flush(batch)
+						batch = nil
+					}
+				default:
+					a.flush(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+// Close stops accepting reports and waits until the queued reports are sent
+//
+// Parameters:
+//
+//   - ctx: the context to stop waiting for the queued reports
+//
+// Returns:
+//
+//   - error: if the context is done before the queued reports are sent
+func (a *AsyncReporter) Close(ctx context.Context) error {
+	a.mutex.Lock()
+	if !a.isClosed {
+		a.isClosed = true
+		close(a.closed)
+	}
+	a.mutex.Unlock()
+
+	select {
+	case <-a.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
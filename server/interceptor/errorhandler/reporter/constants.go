@@ -0,0 +1,19 @@
+package reporter
+
+import (
+	"time"
+)
+
+const (
+	// DefaultQueueSize is the default maximum number of queued reports of the async reporter
+	DefaultQueueSize = 1024
+
+	// DefaultBatchSize is the default maximum number of reports sent at once by the async reporter
+	DefaultBatchSize = 64
+
+	// DefaultFlushInterval is the default interval at which the async reporter sends the queued reports
+	DefaultFlushInterval = time.Second
+
+	// DefaultReportTimeout is the default timeout of each batch sent by the async reporter
+	DefaultReportTimeout = 10 * time.Second
+)
@@ -0,0 +1,16 @@
+package reporter
+
+import (
+	"errors"
+	"fmt"
+
+	gogrpcerrorhandler "github.com/ralvarezdev/go-grpc/server/interceptor/errorhandler"
+)
+
+var (
+	ErrEmptyPath      = errors.New("reports file path cannot be empty")
+	ErrNilReporter    = errors.New("reporter cannot be nil")
+	ErrNilReport      = errors.New("report cannot be nil")
+	ErrQueueFull      = fmt.Errorf("reports queue is full: %w", gogrpcerrorhandler.ErrReportDropped)
+	ErrReporterClosed = errors.New("reporter is closed")
+)
@@ -0,0 +1,174 @@
+package reporter
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	gogrpcerrorhandler "github.com/ralvarezdev/go-grpc/server/interceptor/errorhandler"
+)
+
+type (
+	// FileReporter is the reporter that appends the reports as JSON lines to a file
+	FileReporter struct {
+		mutex  sync.Mutex
+		file   *os.File
+		logger *slog.Logger
+	}
+)
+
+// NewFileReporter creates a new JSON lines file reporter, creating the file if it does not exist
+//
+// Parameters:
+//
+//   - path: the path of the reports file
+//   - logger: the logger to use (optional, can be nil)
+//
+// Returns:
+//
+//   - *FileReporter: the file reporter
+//   - error: if the path is empty or the file cannot be opened
+func NewFileReporter(path string, logger *slog.Logger) (*FileReporter, error) {
+	// Check if the path is empty
+	if path == "" {
+		return nil, ErrEmptyPath
+	}
+
+	// Open the reports file
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	if logger != nil {
+		logger = logger.With(
+			slog.String("error_reporter", "file"),
+		)
+	}
+
+	return &FileReporter{
+		file:   file,
+		logger: logger,
+	}, nil
+}
+
+// newRecord creates a new report line from a report
+//
+// Parameters:
+//
+//   - report: the report
+//
+// Returns:
+//
+//   - *record: the report line
+func (f *FileReporter) newRecord(report *gogrpcerrorhandler.Report) *record {
+	r := &record{
+		ErrorID: report.ErrorID,
+		Method:  report.Method,
+		Error:   report.Error,
+		Panic:   report.Panic,
+		Stack:   report.Stack,
+		Time:    report.Time,
+	}
+
+	// Marshal the protobuf requests with their JSON mapping
+	if message, ok := report.Request.(proto.Message); ok {
+		data, err := protojson.Marshal(message)
+		if err == nil {
+			r.Request = json.RawMessage(data)
+		} else if f.logger != nil {
+			f.logger.Warn(
+				"Failed to marshal report request",
+				slog.String("error_id", report.ErrorID),
+				slog.String("error", err.Error()),
+			)
+		}
+	} else {
+		r.Request = report.Request
+	}
+
+	// Set the principal, without its raw attributes
+	if report.Principal != nil {
+		r.Principal = &principalRecord{
+			Subject:    report.Principal.Subject,
+			AuthScheme: report.Principal.AuthScheme.String(),
+			Roles:      report.Principal.Roles,
+			Tenant:     report.Principal.Tenant,
+		}
+	}
+	return r
+}
+
+// ReportBatch appends the given reports to the file
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - reports: the reports
+//
+// Returns:
+//
+//   - error: if the reports cannot be marshaled or written
+func (f *FileReporter) ReportBatch(
+	ctx context.Context,
+	reports []*gogrpcerrorhandler.Report,
+) error {
+	// Marshal the reports
+	var data []byte
+	for _, report := range reports {
+		if report == nil {
+			continue
+		}
+		line, err := json.Marshal(f.newRecord(report))
+		if err != nil {
+			return err
+		}
+		data = append(data, line...)
+		data = append(data, '\n')
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	// Write the reports
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	_, err := f.file.Write(data)
+	return err
+}
+
+// Report appends the given report to the file
+//
+// Parameters:
+//
+//   - ctx: the context
+//   - report: the report
+//
+// Returns:
+//
+//   - error: if the report is nil, or cannot be marshaled or written
+func (f *FileReporter) Report(
+	ctx context.Context,
+	report *gogrpcerrorhandler.Report,
+) error {
+	if report == nil {
+		return ErrNilReport
+	}
+	return f.ReportBatch(ctx, []*gogrpcerrorhandler.Report{report})
+}
+
+// Close closes the reports file
+//
+// Returns:
+//
+//   - error: if the file cannot be closed
+func (f *FileReporter) Close() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.file.Close()
+}
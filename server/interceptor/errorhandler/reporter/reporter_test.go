@@ -0,0 +1,251 @@
+package reporter
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	gogrpcservercontext "github.com/ralvarezdev/go-grpc/server/context"
+	gogrpcerrorhandler "github.com/ralvarezdev/go-grpc/server/interceptor/errorhandler"
+)
+
+type (
+	// stubReporter records the reports, blocking each call until it is released if a release channel is set
+	stubReporter struct {
+		mutex   sync.Mutex
+		reports []*gogrpcerrorhandler.Report
+		started chan struct{}
+		release chan struct{}
+	}
+)
+
+func (s *stubReporter) Report(_ context.Context, report *gogrpcerrorhandler.Report) error {
+	if s.started != nil {
+		s.started <- struct{}{}
+		<-s.release
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.reports = append(s.reports, report)
+	return nil
+}
+
+func (s *stubReporter) Len() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.reports)
+}
+
+func TestFileReporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reports.jsonl")
+	reporter, err := NewFileReporter(path, nil)
+	if err != nil {
+		t.Fatalf("NewFileReporter: %v", err)
+	}
+
+	reports := []*gogrpcerrorhandler.Report{
+		{
+			ErrorID: "first",
+			Method:  "/pkg.Service/Method",
+			Request: wrapperspb.String("value"),
+			Error:   "boom",
+			Panic:   true,
+			Stack:   "stack",
+			Principal: &gogrpcservercontext.Principal{
+				Subject:    "user",
+				AuthScheme: gogrpcservercontext.AuthSchemeJWT,
+				Attributes: map[string]any{"secret": "hidden"},
+			},
+			Time: time.Now(),
+		},
+		{
+			ErrorID: "second",
+			Method:  "/pkg.Service/Method",
+			Error:   "database is down",
+			Time:    time.Now(),
+		},
+	}
+	if err = reporter.ReportBatch(context.Background(), reports); err != nil {
+		t.Fatalf("ReportBatch: %v", err)
+	}
+	if err = reporter.Report(context.Background(), nil); !errors.Is(err, ErrNilReport) {
+		t.Fatalf("expected ErrNilReport, got %v", err)
+	}
+	if err = reporter.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// Read the report lines
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer file.Close()
+	var lines []map[string]any
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var line map[string]any
+		if err = json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("Unmarshal: %v", err)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 report lines, got %d", len(lines))
+	}
+
+	// The protobuf request is marshaled with its JSON mapping and the principal attributes are left out
+	first := lines[0]
+	if first["error_id"] != "first" || first["request"] != "value" || first["panic"] != true {
+		t.Fatalf("unexpected report line: %v", first)
+	}
+	principal, ok := first["principal"].(map[string]any)
+	if !ok || principal["subject"] != "user" || principal["auth_scheme"] != "jwt" {
+		t.Fatalf("unexpected principal: %v", first["principal"])
+	}
+	if _, ok = principal["attributes"]; ok {
+		t.Fatal("expected the principal attributes to be left out")
+	}
+	if _, ok = lines[1]["request"]; ok {
+		t.Fatal("expected the missing request to be omitted")
+	}
+}
+
+func TestNewFileReporterEmptyPath(t *testing.T) {
+	if _, err := NewFileReporter("", nil); !errors.Is(err, ErrEmptyPath) {
+		t.Fatalf("expected ErrEmptyPath, got %v", err)
+	}
+}
+
+func TestAsyncReporterFlushesOnClose(t *testing.T) {
+	reporter := &stubReporter{}
+	async, err := NewAsyncReporter(
+		reporter,
+		&Options{BatchSize: 4, FlushInterval: time.Hour},
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("NewAsyncReporter: %v", err)
+	}
+
+	for n := range 10 {
+		if err = async.Report(context.Background(), &gogrpcerrorhandler.Report{ErrorID: fmt.Sprint(n)}); err != nil {
+			t.Fatalf("Report: %v", err)
+		}
+	}
+	if err = async.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if length := reporter.Len(); length != 10 {
+		t.Fatalf("expected the 10 queued reports to be sent, got %d", length)
+	}
+	if err = async.Report(context.Background(), &gogrpcerrorhandler.Report{}); !errors.Is(err, ErrReporterClosed) {
+		t.Fatalf("expected ErrReporterClosed, got %v", err)
+	}
+}
+
+func TestAsyncReporterDropsWhenFull(t *testing.T) {
+	reporter := &stubReporter{
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	async, err := NewAsyncReporter(
+		reporter,
+		&Options{QueueSize: 1, BatchSize: 1},
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("NewAsyncReporter: %v", err)
+	}
+
+	// Block the background goroutine sending the first report, then fill the queue
+	if err = async.Report(context.Background(), &gogrpcerrorhandler.Report{ErrorID: "first"}); err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+	<-reporter.started
+	if err = async.Report(context.Background(), &gogrpcerrorhandler.Report{ErrorID: "queued"}); err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+
+	// The report is dropped without blocking
+	if err = async.Report(context.Background(), &gogrpcerrorhandler.Report{ErrorID: "dropped"}); !errors.Is(
+		err,
+		ErrQueueFull,
+	) {
+		t.Fatalf("expected ErrQueueFull, got %v", err)
+	}
+	if dropped := async.Dropped(); dropped != 1 {
+		t.Fatalf("expected a dropped report, got %d", dropped)
+	}
+
+	// Release the background goroutine
+	go func() {
+		for range reporter.started {
+		}
+	}()
+	close(reporter.release)
+	if err = async.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	close(reporter.started)
+	if length := reporter.Len(); length != 2 {
+		t.Fatalf("expected the 2 accepted reports to be sent, got %d", length)
+	}
+}
+
+func TestAsyncReporterConcurrentReportAndClose(t *testing.T) {
+	for range 20 {
+		reporter := &stubReporter{}
+		async, err := NewAsyncReporter(
+			reporter,
+			&Options{QueueSize: 1024, BatchSize: 8, FlushInterval: time.Hour},
+			nil,
+		)
+		if err != nil {
+			t.Fatalf("NewAsyncReporter: %v", err)
+		}
+
+		// Report concurrently while the reporter is closed, counting the accepted reports
+		var accepted atomic.Int64
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 32 {
+					if err := async.Report(context.Background(), &gogrpcerrorhandler.Report{}); err == nil {
+						accepted.Add(1)
+					} else if !errors.Is(err, ErrReporterClosed) {
+						t.Errorf("expected ErrReporterClosed, got %v", err)
+					}
+				}
+			}()
+		}
+		if err = async.Close(context.Background()); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+		wg.Wait()
+
+		// Every accepted report is sent
+		if length := reporter.Len(); int64(length) != accepted.Load() {
+			t.Fatalf("expected the %d accepted reports to be sent, got %d", accepted.Load(), length)
+		}
+	}
+}
+
+func TestQueueFullIsReportDropped(t *testing.T) {
+	if !errors.Is(ErrQueueFull, gogrpcerrorhandler.ErrReportDropped) {
+		t.Fatal("expected ErrQueueFull to wrap ErrReportDropped")
+	}
+}
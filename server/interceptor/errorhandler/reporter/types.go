@@ -0,0 +1,42 @@
+package reporter
+
+import (
+	"time"
+)
+
+type (
+	// Options are the options for the async reporter
+	Options struct {
+		// QueueSize is the maximum number of queued reports, the reports are dropped while the queue is full
+		QueueSize int
+
+		// BatchSize is the maximum number of reports sent at once
+		BatchSize int
+
+		// FlushInterval is the interval at which the queued reports are sent, even if the batch is not full
+		FlushInterval time.Duration
+
+		// ReportTimeout is the timeout of each batch sent to the wrapped reporter
+		ReportTimeout time.Duration
+	}
+
+	// principalRecord is the principal of a report line of the file reporter
+	principalRecord struct {
+		Subject    string   `json:"subject"`
+		AuthScheme string   `json:"auth_scheme"`
+		Roles      []string `json:"roles,omitempty"`
+		Tenant     string   `json:"tenant,omitempty"`
+	}
+
+	// record is a report line of the file reporter
+	record struct {
+		ErrorID   string           `json:"error_id"`
+		Method    string           `json:"method"`
+		Request   any              `json:"request,omitempty"`
+		Error     string           `json:"error"`
+		Panic     bool             `json:"panic"`
+		Stack     string           `json:"stack,omitempty"`
+		Principal *principalRecord `json:"principal,omitempty"`
+		Time      time.Time        `json:"time"`
+	}
+)
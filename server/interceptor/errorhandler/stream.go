@@ -0,0 +1,84 @@
+package errorhandler
+
+import (
+	"google.golang.org/grpc"
+)
+
+type (
+	// recoveringServerStream is a gRPC server stream wrapper that recovers the panics in the RecvMsg and SendMsg
+	// calls, returning them as errors
+	recoveringServerStream struct {
+		grpc.ServerStream
+		interceptor Interceptor
+		fullMethod  string
+	}
+)
+
+// newRecoveringServerStream creates a new recovering server stream
+//
+// Parameters:
+//
+//   - ss: the server stream to wrap
+//   - interceptor: the error handler interceptor that handles the recovered panics
+//   - fullMethod: the full method name of the stream
+//
+// Returns:
+//
+//   - *recoveringServerStream: the recovering server stream
+func newRecoveringServerStream(
+	ss grpc.ServerStream,
+	interceptor Interceptor,
+	fullMethod string,
+) *recoveringServerStream {
+	return &recoveringServerStream{
+		ServerStream: ss,
+		interceptor:  interceptor,
+		fullMethod:   fullMethod,
+	}
+}
+
+// RecvMsg receives a message from the stream, recovering any panic
+//
+// Parameters:
+//
+//   - m: the message to receive into
+//
+// Returns:
+//
+//   - error: the error returned by the stream, or the gRPC status error of the recovered panic
+func (r *recoveringServerStream) RecvMsg(m any) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = r.interceptor.handlePanic(
+				r.ServerStream.Context(),
+				r.fullMethod,
+				m,
+				recovered,
+			)
+		}
+	}()
+	return r.ServerStream.RecvMsg(m)
+}
+
+// SendMsg sends a message to the stream, recovering any panic
+//
+// Parameters:
+//
+//   - m: the message to send
+//
+// Returns:
+//
+//   - error: the error returned by the stream, or the gRPC status error of the recovered panic
+func (r *recoveringServerStream) SendMsg(m any) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = r.interceptor.handlePanic(
+				r.ServerStream.Context(),
+				r.fullMethod,
+				m,
+				recovered,
+			)
+		}
+	}()
+	return r.ServerStream.SendMsg(m)
+}
@@ -0,0 +1,71 @@
+package errorhandler
+
+import (
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/protoadapt"
+
+	gogrpcservercontext "github.com/ralvarezdev/go-grpc/server/context"
+)
+
+type (
+	// Mapping is the gRPC status to which a registered error is mapped
+	Mapping struct {
+		// Code is the code of the status
+		Code codes.Code
+
+		// Message is the message of the status, if empty the error message is used
+		Message string
+
+		// Details returns the details of the status for the matched error (optional, can be nil)
+		Details func(err error) []protoadapt.MessageV1
+	}
+
+	// Report is a recovered panic or an internal error reported to the reporter
+	Report struct {
+		// ErrorID is the error ID returned to the client
+		ErrorID string
+
+		// Method is the full method name of the request
+		Method string
+
+		// Request is a copy of the request or the stream message, if any, the non-protobuf values are not copied
+		Request any
+
+		// Error is the error message or the recovered panic value
+		Error string
+
+		// Panic is true if the report is of a recovered panic
+		Panic bool
+
+		// Stack is the stack trace of the recovered panic
+		Stack string
+
+		// Principal is the authenticated caller, if any
+		Principal *gogrpcservercontext.Principal
+
+		// Time is the time at which the error occurred
+		Time time.Time
+	}
+
+	// AbandonedRPCStats are the number of RPCs that finished after the client gave up, and the number of RPCs whose
+	// handler timed out on its own
+	AbandonedRPCStats struct {
+		// Timeouts is the number of RPCs whose deadline exceeded while the server was handling them
+		Timeouts uint64
+
+		// Cancellations is the number of RPCs canceled by the client while the server was handling them
+		Cancellations uint64
+
+		// ServerTimeouts is the number of RPCs whose handler returned a deadline exceeded error while the RPC was still
+		// live, e.g. because a call made by the handler timed out
+		ServerTimeouts uint64
+	}
+
+	// registryEntry is an entry of the registry
+	registryEntry struct {
+		matches func(err error) bool
+		mapping *Mapping
+	}
+)
@@ -0,0 +1,9 @@
+package validator
+
+const (
+	// StreamModeEnd ends the stream with the validation error when an invalid message is received, the default one
+	StreamModeEnd StreamMode = "end"
+
+	// StreamModeSkip skips the invalid messages received, reporting them
+	StreamModeSkip StreamMode = "skip"
+)
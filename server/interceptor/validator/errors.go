@@ -0,0 +1,10 @@
+package validator
+
+import (
+	"errors"
+)
+
+var (
+	ErrNilService        = errors.New("validator service cannot be nil")
+	ErrInvalidStreamMode = errors.New("invalid stream validation mode")
+)
@@ -0,0 +1,187 @@
+package validator
+
+import (
+	"context"
+	"log/slog"
+
+	"connectrpc.com/connect"
+	"google.golang.org/grpc"
+
+	gogrpc "github.com/ralvarezdev/go-grpc"
+	gogrpcvalidator "github.com/ralvarezdev/go-grpc/server/validator"
+	gogrpcstatusconnect "github.com/ralvarezdev/go-grpc/status/connect"
+)
+
+type (
+	// Interceptor is the interceptor that validates the incoming request messages before the handlers
+	Interceptor struct {
+		service               gogrpcvalidator.Service
+		methodService         gogrpcvalidator.MethodService
+		excludedMethods       *gogrpc.MethodMatcher[struct{}]
+		auxiliaryValidatorFns *gogrpc.MethodMatcher[[]any]
+		streamMode            StreamMode
+		onInvalidMessage      InvalidMessageFn
+		logger                *slog.Logger
+	}
+)
+
+// NewInterceptor creates a new request validation interceptor
+//
+// Parameters:
+//
+//   - service: the validator service
+//   - excludedMethods: the method matcher of the methods whose requests are not validated (optional, can be nil)
+//   - auxiliaryValidatorFns: the method matcher of the auxiliary validator functions of each method (optional, can
+//     be nil)
+//   - streamOptions: the options for the stream validation (optional, can be nil)
+//   - logger: the logger to use (optional, can be nil)
+//
+// Returns:
+//
+//   - *Interceptor: the interceptor
+//   - error: if the validator service is nil or the stream mode is invalid
+func NewInterceptor(
+	service gogrpcvalidator.Service,
+	excludedMethods *gogrpc.MethodMatcher[struct{}],
+	auxiliaryValidatorFns *gogrpc.MethodMatcher[[]any],
+	streamOptions *StreamOptions,
+	logger *slog.Logger,
+) (*Interceptor, error) {
+	// Check if the validator service is nil
+	if service == nil {
+		return nil, ErrNilService
+	}
+
+	// Set the stream options
+	streamMode := StreamModeEnd
+	var onInvalidMessage InvalidMessageFn
+	if streamOptions != nil {
+		switch streamOptions.Mode {
+		case "":
+		case StreamModeEnd, StreamModeSkip:
+			streamMode = streamOptions.Mode
+		default:
+			return nil, ErrInvalidStreamMode
+		}
+		onInvalidMessage = streamOptions.OnInvalidMessage
+	}
+
+	if logger != nil {
+		logger = logger.With(
+			slog.String("grpc_server_interceptor", "validator"),
+		)
+	}
+
+	// Check if the validator service caches the validate functions of each method
+	methodService, _ := service.(gogrpcvalidator.MethodService)
+
+	return &Interceptor{
+		service:               service,
+		methodService:         methodService,
+		excludedMethods:       excludedMethods,
+		auxiliaryValidatorFns: auxiliaryValidatorFns,
+		streamMode:            streamMode,
+		onInvalidMessage:      onInvalidMessage,
+		logger:                logger,
+	}, nil
+}
+
+// validate validates the request of the given method
+//
+// Parameters:
+//
+//   - fullMethod: the full method name of the request
+//   - request: the request message
+//
+// Returns:
+//
+//   - error: the error returned by the validator service, if the request is invalid
+func (i Interceptor) validate(fullMethod string, request any) error {
+	// Check if the method is excluded
+	if _, ok := i.excludedMethods.Match(fullMethod); ok {
+		return nil
+	}
+
+	// Validate the request with the auxiliary validator functions of the method, which are not shared with the other
+	// methods with the same request type
+	auxiliaryValidatorFns, _ := i.auxiliaryValidatorFns.Match(fullMethod)
+	var err error
+	if i.methodService != nil {
+		err = i.methodService.ValidateMethod(
+			fullMethod,
+			request,
+			auxiliaryValidatorFns...,
+		)
+	} else {
+		err = i.service.Validate(request, auxiliaryValidatorFns...)
+	}
+	if err != nil && i.logger != nil {
+		i.logger.Debug(
+			"Request validation failed",
+			slog.String("method", fullMethod),
+			slog.String("error", err.Error()),
+		)
+	}
+	return err
+}
+
+// Validate returns the request validation interceptor
+//
+// Returns:
+//
+//   - grpc.UnaryServerInterceptor: the interceptor
+func (i Interceptor) Validate() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req any, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if err := i.validate(info.FullMethod, req); err != nil {
+			return nil, gogrpcstatusconnect.ToStatusError(err)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// ValidateStream returns the stream validation interceptor, which validates each message received by the handler
+//
+// Returns:
+//
+//   - grpc.StreamServerInterceptor: the interceptor
+func (i Interceptor) ValidateStream() grpc.StreamServerInterceptor {
+	return func(
+		srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		// Check if the method is excluded
+		if _, ok := i.excludedMethods.Match(info.FullMethod); ok {
+			return handler(srv, ss)
+		}
+		return handler(srv, newValidatingServerStream(ss, i, info.FullMethod))
+	}
+}
+
+// ValidateConnect returns the request validation interceptor for connect handlers
+//
+// Returns:
+//
+//   - connect.Interceptor: the interceptor
+func (i Interceptor) ValidateConnect() connect.Interceptor {
+	return connect.UnaryInterceptorFunc(
+		func(next connect.UnaryFunc) connect.UnaryFunc {
+			return func(
+				ctx context.Context,
+				req connect.AnyRequest,
+			) (connect.AnyResponse, error) {
+				// Only validate the requests received by the handlers
+				if req.Spec().IsClient {
+					return next(ctx, req)
+				}
+
+				if err := i.validate(req.Spec().Procedure, req.Any()); err != nil {
+					return nil, gogrpcstatusconnect.ToConnectError(err)
+				}
+				return next(ctx, req)
+			}
+		},
+	)
+}
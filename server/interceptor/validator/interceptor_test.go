@@ -0,0 +1,224 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"connectrpc.com/connect"
+	govalidatormappervalidation "github.com/ralvarezdev/go-validator/mapper/validation"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	gogrpc "github.com/ralvarezdev/go-grpc"
+	gogrpcvalidator "github.com/ralvarezdev/go-grpc/server/validator"
+	gogrpcstatusconnect "github.com/ralvarezdev/go-grpc/status/connect"
+)
+
+const (
+	testCheckedMethod   = "/pkg.Service/Checked"
+	testUncheckedMethod = "/pkg.Service/Unchecked"
+	testExcludedMethod  = "/pkg.Service/Excluded"
+)
+
+// rejectValue is an auxiliary validator function that rejects every value
+func rejectValue(
+	_ *wrapperspb.StringValue,
+	validations *govalidatormappervalidation.StructValidations,
+) {
+	validations.AddFieldValidationError("value", errors.New("value is rejected"))
+}
+
+// newTestInterceptor creates a validation interceptor with the given backend, whose checked method rejects every
+// request and whose unchecked method has no auxiliary validator functions, both with the same request type
+func newTestInterceptor(t *testing.T, backend gogrpcvalidator.Backend) *Interceptor {
+	t.Helper()
+
+	service, err := gogrpcvalidator.NewBackendService(
+		backend,
+		nil,
+		nil,
+		gogrpcvalidator.ErrorFormatGRPC,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("NewBackendService: %v", err)
+	}
+	excludedMethods, err := gogrpc.NewMethodSetMatcher([]string{testExcludedMethod})
+	if err != nil {
+		t.Fatalf("NewMethodSetMatcher: %v", err)
+	}
+	auxiliaryValidatorFns, err := gogrpc.NewMethodMatcher(
+		map[string][]any{
+			testCheckedMethod:  {rejectValue},
+			testExcludedMethod: {rejectValue},
+		},
+	)
+	if err != nil {
+		t.Fatalf("NewMethodMatcher: %v", err)
+	}
+	interceptor, err := NewInterceptor(
+		service,
+		excludedMethods,
+		auxiliaryValidatorFns,
+		nil,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("NewInterceptor: %v", err)
+	}
+	return interceptor
+}
+
+// checkRejected checks the given error is the InvalidArgument status of the value rejected by rejectValue, with its
+// field violation as bad request detail
+func checkRejected(t *testing.T, err error) {
+	t.Helper()
+
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	expected := []*errdetails.BadRequest_FieldViolation{
+		{Field: "value", Description: "value is rejected"},
+	}
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			if fieldViolations := badRequest.GetFieldViolations(); !slices.EqualFunc(
+				fieldViolations,
+				expected,
+				func(a, b *errdetails.BadRequest_FieldViolation) bool {
+					return proto.Equal(a, b)
+				},
+			) {
+				t.Fatalf("expected the field violations %v, got %v", expected, fieldViolations)
+			}
+			return
+		}
+	}
+	t.Fatalf("expected a bad request detail, got %v", err)
+}
+
+// call calls the given method through the validation interceptor
+func call(interceptor *Interceptor, fullMethod string) error {
+	_, err := interceptor.Validate()(
+		context.Background(),
+		wrapperspb.String("value"),
+		&grpc.UnaryServerInfo{FullMethod: fullMethod},
+		func(context.Context, any) (any, error) {
+			return nil, nil
+		},
+	)
+	return err
+}
+
+func TestValidateAuxiliaryValidatorFnsPerMethod(t *testing.T) {
+	for _, backend := range []gogrpcvalidator.Backend{
+		gogrpcvalidator.BackendGoValidator,
+		gogrpcvalidator.BackendProtovalidate,
+	} {
+		tests := []struct {
+			name    string
+			methods []string
+		}{
+			{
+				name:    "checked method first",
+				methods: []string{testCheckedMethod, testUncheckedMethod},
+			},
+			{
+				name:    "unchecked method first",
+				methods: []string{testUncheckedMethod, testCheckedMethod},
+			},
+		}
+		for _, test := range tests {
+			t.Run(
+				string(backend)+" "+test.name, func(t *testing.T) {
+					interceptor := newTestInterceptor(t, backend)
+
+					// Call each method twice, so the cached validate functions are used too
+					for range 2 {
+						for _, method := range test.methods {
+							err := call(interceptor, method)
+							switch method {
+							case testCheckedMethod:
+								checkRejected(t, err)
+							default:
+								if err != nil {
+									t.Fatalf("expected no error for %s, got %v", method, err)
+								}
+							}
+						}
+					}
+
+					// The excluded methods are not validated
+					if err := call(interceptor, testExcludedMethod); err != nil {
+						t.Fatalf("expected the excluded method not to be validated, got %v", err)
+					}
+				},
+			)
+		}
+	}
+}
+
+// callConnect calls the given procedure through a connect handler with the validation interceptor
+func callConnect(t *testing.T, interceptor *Interceptor, procedure string) error {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.Handle(
+		procedure,
+		connect.NewUnaryHandler(
+			procedure,
+			func(
+				context.Context,
+				*connect.Request[wrapperspb.StringValue],
+			) (*connect.Response[wrapperspb.StringValue], error) {
+				return connect.NewResponse(&wrapperspb.StringValue{}), nil
+			},
+			connect.WithInterceptors(interceptor.ValidateConnect()),
+		),
+	)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := connect.NewClient[wrapperspb.StringValue, wrapperspb.StringValue](
+		server.Client(),
+		server.URL+procedure,
+	)
+	_, err := client.CallUnary(context.Background(), connect.NewRequest(wrapperspb.String("value")))
+	return err
+}
+
+func TestValidateConnect(t *testing.T) {
+	for _, backend := range []gogrpcvalidator.Backend{
+		gogrpcvalidator.BackendGoValidator,
+		gogrpcvalidator.BackendProtovalidate,
+	} {
+		t.Run(
+			string(backend), func(t *testing.T) {
+				interceptor := newTestInterceptor(t, backend)
+
+				// The connect error keeps the code and the field violations of the validation
+				err := callConnect(t, interceptor, testCheckedMethod)
+				if connect.CodeOf(err) != connect.CodeInvalidArgument {
+					t.Fatalf("expected InvalidArgument, got %v", err)
+				}
+				checkRejected(t, gogrpcstatusconnect.ToStatusError(err))
+
+				// The unchecked and excluded methods are not rejected
+				for _, method := range []string{testUncheckedMethod, testExcludedMethod} {
+					if err = callConnect(t, interceptor, method); err != nil {
+						t.Fatalf("expected no error for %s, got %v", method, err)
+					}
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,15 @@
+package validator
+
+import (
+	"connectrpc.com/connect"
+	"google.golang.org/grpc"
+)
+
+type (
+	// Validation interface
+	Validation interface {
+		Validate() grpc.UnaryServerInterceptor
+		ValidateStream() grpc.StreamServerInterceptor
+		ValidateConnect() connect.Interceptor
+	}
+)
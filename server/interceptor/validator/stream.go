@@ -0,0 +1,85 @@
+package validator
+
+import (
+	"log/slog"
+
+	"google.golang.org/grpc"
+
+	gogrpcstatusconnect "github.com/ralvarezdev/go-grpc/status/connect"
+)
+
+type (
+	// validatingServerStream is a gRPC server stream wrapper that validates each received message
+	validatingServerStream struct {
+		grpc.ServerStream
+		interceptor Interceptor
+		fullMethod  string
+	}
+)
+
+// newValidatingServerStream creates a new validating server stream
+//
+// Parameters:
+//
+//   - ss: the server stream to wrap
+//   - interceptor: the validation interceptor
+//   - fullMethod: the full method name of the stream
+//
+// Returns:
+//
+//   - *validatingServerStream: the validating server stream
+func newValidatingServerStream(
+	ss grpc.ServerStream,
+	interceptor Interceptor,
+	fullMethod string,
+) *validatingServerStream {
+	return &validatingServerStream{
+		ServerStream: ss,
+		interceptor:  interceptor,
+		fullMethod:   fullMethod,
+	}
+}
+
+// RecvMsg receives the next valid message from the stream, ending the stream or skipping the invalid messages
+// depending on the stream mode
+//
+// Parameters:
+//
+//   - m: the message to receive into
+//
+// Returns:
+//
+//   - error: the error returned by the stream, or the gRPC status error of the validation if the stream is ended
+func (v *validatingServerStream) RecvMsg(m any) error {
+	for {
+		if err := v.ServerStream.RecvMsg(m); err != nil {
+			return err
+		}
+
+		// Validate the received message
+		err := v.interceptor.validate(v.fullMethod, m)
+		if err == nil {
+			return nil
+		}
+		if v.interceptor.streamMode != StreamModeSkip {
+			return gogrpcstatusconnect.ToStatusError(err)
+		}
+
+		// Report the skipped message
+		if v.interceptor.logger != nil {
+			v.interceptor.logger.Warn(
+				"Skipped invalid stream message",
+				slog.String("method", v.fullMethod),
+				slog.String("error", err.Error()),
+			)
+		}
+		if v.interceptor.onInvalidMessage != nil {
+			v.interceptor.onInvalidMessage(
+				v.ServerStream.Context(),
+				v.fullMethod,
+				m,
+				err,
+			)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+
+	govalidatormappervalidation "github.com/ralvarezdev/go-validator/mapper/validation"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	gogrpc "github.com/ralvarezdev/go-grpc"
+	gogrpcvalidator "github.com/ralvarezdev/go-grpc/server/validator"
+)
+
+const (
+	testStreamMethod         = "/pkg.Service/Stream"
+	testExcludedStreamMethod = "/pkg.Service/ExcludedStream"
+	testInvalidValue         = "invalid"
+)
+
+type (
+	// fakeServerStream is a server stream that receives the queued values, and then io.EOF
+	fakeServerStream struct {
+		grpc.ServerStream
+		ctx    context.Context
+		values []string
+	}
+
+	// invalidMessage is a skipped invalid message reported to the OnInvalidMessage function
+	invalidMessage struct {
+		fullMethod string
+		value      string
+		err        error
+	}
+)
+
+func (f *fakeServerStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeServerStream) RecvMsg(m any) error {
+	if len(f.values) == 0 {
+		return io.EOF
+	}
+	m.(*wrapperspb.StringValue).Value = f.values[0]
+	f.values = f.values[1:]
+	return nil
+}
+
+// rejectInvalidValue is an auxiliary validator function that rejects the test invalid value
+func rejectInvalidValue(
+	value *wrapperspb.StringValue,
+	validations *govalidatormappervalidation.StructValidations,
+) {
+	if value.GetValue() == testInvalidValue {
+		validations.AddFieldValidationError("value", errors.New("value is rejected"))
+	}
+}
+
+// newTestStreamInterceptor creates a validation interceptor with the given stream options, whose stream method
+// rejects the test invalid value
+func newTestStreamInterceptor(t *testing.T, streamOptions *StreamOptions) *Interceptor {
+	t.Helper()
+
+	service, err := gogrpcvalidator.NewBackendService(
+		gogrpcvalidator.BackendGoValidator,
+		nil,
+		nil,
+		gogrpcvalidator.ErrorFormatGRPC,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("NewBackendService: %v", err)
+	}
+	excludedMethods, err := gogrpc.NewMethodSetMatcher([]string{testExcludedStreamMethod})
+	if err != nil {
+		t.Fatalf("NewMethodSetMatcher: %v", err)
+	}
+	auxiliaryValidatorFns, err := gogrpc.NewMethodMatcher(
+		map[string][]any{
+			testStreamMethod:         {rejectInvalidValue},
+			testExcludedStreamMethod: {rejectInvalidValue},
+		},
+	)
+	if err != nil {
+		t.Fatalf("NewMethodMatcher: %v", err)
+	}
+	interceptor, err := NewInterceptor(
+		service,
+		excludedMethods,
+		auxiliaryValidatorFns,
+		streamOptions,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("NewInterceptor: %v", err)
+	}
+	return interceptor
+}
+
+// stream calls the given stream method through the validation interceptor with a stream receiving the given values,
+// and returns the values received by the handler
+func stream(interceptor *Interceptor, fullMethod string, values ...string) ([]string, error) {
+	var received []string
+	err := interceptor.ValidateStream()(
+		nil,
+		&fakeServerStream{ctx: context.Background(), values: values},
+		&grpc.StreamServerInfo{FullMethod: fullMethod, IsClientStream: true},
+		func(_ any, ss grpc.ServerStream) error {
+			for {
+				value := &wrapperspb.StringValue{}
+				if err := ss.RecvMsg(value); err != nil {
+					if errors.Is(err, io.EOF) {
+						return nil
+					}
+					return err
+				}
+				received = append(received, value.GetValue())
+			}
+		},
+	)
+	return received, err
+}
+
+func TestValidateStreamModeEnd(t *testing.T) {
+	for _, streamOptions := range []*StreamOptions{nil, {Mode: StreamModeEnd}} {
+		interceptor := newTestStreamInterceptor(t, streamOptions)
+
+		// The stream is ended with the validation error of the first invalid message
+		received, err := stream(interceptor, testStreamMethod, "first", testInvalidValue, "last")
+		checkRejected(t, err)
+		if !slices.Equal(received, []string{"first"}) {
+			t.Fatalf("expected only the message before the invalid one, got %v", received)
+		}
+
+		// The valid messages are received
+		received, err = stream(interceptor, testStreamMethod, "first", "last")
+		if err != nil || !slices.Equal(received, []string{"first", "last"}) {
+			t.Fatalf("expected the valid messages, got %v (%v)", received, err)
+		}
+	}
+}
+
+func TestValidateStreamModeSkip(t *testing.T) {
+	var invalidMessages []invalidMessage
+	interceptor := newTestStreamInterceptor(
+		t,
+		&StreamOptions{
+			Mode: StreamModeSkip,
+			OnInvalidMessage: func(_ context.Context, fullMethod string, message any, err error) {
+				invalidMessages = append(
+					invalidMessages, invalidMessage{
+						fullMethod: fullMethod,
+						value:      message.(*wrapperspb.StringValue).GetValue(),
+						err:        err,
+					},
+				)
+			},
+		},
+	)
+
+	// The handler only receives the valid messages
+	received, err := stream(
+		interceptor,
+		testStreamMethod,
+		testInvalidValue,
+		"first",
+		testInvalidValue,
+		testInvalidValue,
+		"last",
+		testInvalidValue,
+	)
+	if err != nil {
+		t.Fatalf("expected the stream to end without error, got %v", err)
+	}
+	if !slices.Equal(received, []string{"first", "last"}) {
+		t.Fatalf("expected only the valid messages, got %v", received)
+	}
+
+	// The skipped messages are reported
+	if len(invalidMessages) != 4 {
+		t.Fatalf("expected the 4 invalid messages to be reported, got %d", len(invalidMessages))
+	}
+	for _, message := range invalidMessages {
+		if message.fullMethod != testStreamMethod || message.value != testInvalidValue {
+			t.Fatalf("unexpected invalid message: %+v", message)
+		}
+		if status.Code(message.err) != codes.InvalidArgument {
+			t.Fatalf("expected the validation error of the invalid message, got %v", message.err)
+		}
+	}
+}
+
+func TestValidateStreamExcludedMethod(t *testing.T) {
+	for _, mode := range []StreamMode{StreamModeEnd, StreamModeSkip} {
+		interceptor := newTestStreamInterceptor(
+			t,
+			&StreamOptions{
+				Mode: mode,
+				OnInvalidMessage: func(context.Context, string, any, error) {
+					t.Fatal("expected the excluded method messages not to be reported")
+				},
+			},
+		)
+
+		// The messages of the excluded methods are not validated
+		received, err := stream(interceptor, testExcludedStreamMethod, "first", testInvalidValue)
+		if err != nil || !slices.Equal(received, []string{"first", testInvalidValue}) {
+			t.Fatalf("expected every message to be received in %s mode, got %v (%v)", mode, received, err)
+		}
+	}
+}
+
+func TestNewInterceptorInvalidStreamMode(t *testing.T) {
+	service, err := gogrpcvalidator.NewBackendService(
+		gogrpcvalidator.BackendGoValidator,
+		nil,
+		nil,
+		gogrpcvalidator.ErrorFormatGRPC,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("NewBackendService: %v", err)
+	}
+	if _, err = NewInterceptor(service, nil, nil, &StreamOptions{Mode: "invalid"}, nil); !errors.Is(
+		err,
+		ErrInvalidStreamMode,
+	) {
+		t.Fatalf("expected ErrInvalidStreamMode, got %v", err)
+	}
+}
@@ -0,0 +1,22 @@
+package validator
+
+import (
+	"context"
+)
+
+type (
+	// InvalidMessageFn is the function called with each skipped invalid stream message
+	InvalidMessageFn func(ctx context.Context, fullMethod string, message any, err error)
+
+	// StreamMode is the behavior of the stream validation interceptor when an invalid message is received
+	StreamMode string
+
+	// StreamOptions are the options for the stream validation interceptor
+	StreamOptions struct {
+		// Mode is the behavior when an invalid message is received, if empty the stream is ended
+		Mode StreamMode
+
+		// OnInvalidMessage is called with each skipped invalid message (optional, can be nil)
+		OnInvalidMessage InvalidMessageFn
+	}
+)
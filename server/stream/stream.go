@@ -0,0 +1,47 @@
+package stream
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+type (
+	// WrappedServerStream is a gRPC server stream wrapper that overrides the stream context
+	WrappedServerStream struct {
+		grpc.ServerStream
+		ctx context.Context
+	}
+)
+
+// NewWrappedServerStream creates a new wrapped server stream
+//
+// Parameters:
+//
+//   - ctx: the context to return from the wrapped stream
+//   - ss: the server stream to wrap
+//
+// Returns:
+//
+//   - *WrappedServerStream: the wrapped server stream
+func NewWrappedServerStream(
+	ctx context.Context,
+	ss grpc.ServerStream,
+) *WrappedServerStream {
+	return &WrappedServerStream{
+		ServerStream: ss,
+		ctx:          ctx,
+	}
+}
+
+// Context returns the context of the wrapped server stream
+//
+// Returns:
+//
+//   - context.Context: the context
+func (w *WrappedServerStream) Context() context.Context {
+	if w.ctx == nil {
+		return w.ServerStream.Context()
+	}
+	return w.ctx
+}
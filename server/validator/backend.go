@@ -0,0 +1,62 @@
+package validator
+
+import (
+	"log/slog"
+
+	govalidatormappervalidator "github.com/ralvarezdev/go-validator/mapper/validator"
+)
+
+// NewBackendService creates a new validator service with the given backend, so the backends can be selected by
+// configuration without changing the call sites of the BackendService interface
+//
+// Parameters:
+//
+//   - backend: the validation backend (optional, defaults to the go-validator backend)
+//   - birthdateOptions: the default birthdate options (optional, can be nil)
+//   - passwordOptions: the default password options (optional, can be nil)
+//   - errorFormat: the form of the returned errors, either connect errors or gRPC status errors (optional, defaults
+//     to connect errors)
+//   - logger: the logger
+//   - options: the optional settings, e.g. WithFieldOptions or WithProtovalidateOptions
+//
+// Returns:
+//
+//   - BackendService: the validator service
+//   - error: if the backend is invalid or there was an error creating the validator service
+func NewBackendService(
+	backend Backend,
+	birthdateOptions *govalidatormappervalidator.BirthdateOptions,
+	passwordOptions *govalidatormappervalidator.PasswordOptions,
+	errorFormat ErrorFormat,
+	logger *slog.Logger,
+	options ...Option,
+) (BackendService, error) {
+	switch backend {
+	case "", BackendGoValidator:
+		service, err := NewService(
+			birthdateOptions,
+			passwordOptions,
+			errorFormat,
+			logger,
+			options...,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return service, nil
+	case BackendProtovalidate:
+		service, err := NewProtovalidateService(
+			birthdateOptions,
+			passwordOptions,
+			errorFormat,
+			logger,
+			options...,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return service, nil
+	default:
+		return nil, ErrInvalidBackend
+	}
+}
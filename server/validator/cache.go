@@ -0,0 +1,109 @@
+package validator
+
+import (
+	"sync"
+)
+
+type (
+	// validateFnBuild is an in-flight build of a validate function
+	validateFnBuild struct {
+		done       chan struct{}
+		validateFn ValidateFn
+		err        error
+	}
+
+	// validateFnCache is the concurrency-safe cache of the validate functions, which builds each validate function
+	// only once even if it is requested concurrently
+	validateFnCache struct {
+		mutex       sync.RWMutex
+		validateFns map[string]ValidateFn
+		builds      map[string]*validateFnBuild
+	}
+)
+
+// newValidateFnCache creates a new validate functions cache
+//
+// Returns:
+//
+//   - *validateFnCache: the cache
+func newValidateFnCache() *validateFnCache {
+	return &validateFnCache{
+		validateFns: make(map[string]ValidateFn),
+		builds:      make(map[string]*validateFnBuild),
+	}
+}
+
+// getCacheKey gets the cache key of a validate function, which is also keyed by the method if it has auxiliary
+// validator functions, since those are specific to the method
+//
+// Parameters:
+//
+//   - fullMethod: the full method name
+//   - requestType: the unique reference of the request type
+//   - hasAuxiliaryValidatorFns: whether the validate function has auxiliary validator functions or not
+//
+// Returns:
+//
+//   - string: the cache key
+func getCacheKey(
+	fullMethod, requestType string,
+	hasAuxiliaryValidatorFns bool,
+) string {
+	if !hasAuxiliaryValidatorFns {
+		return requestType
+	}
+	return fullMethod + CacheKeySeparator + requestType
+}
+
+// get gets the cached validate function of the given key, building it if it is not cached. The concurrent calls
+// for the same key wait for a single build
+//
+// Parameters:
+//
+//   - key: the key of the validate function
+//   - build: the function that builds the validate function
+//
+// Returns:
+//
+//   - ValidateFn: the validate function
+//   - error: if there was an error building the validate function
+func (c *validateFnCache) get(
+	key string,
+	build func() (ValidateFn, error),
+) (ValidateFn, error) {
+	// Check if the validate function is already cached
+	c.mutex.RLock()
+	validateFn, ok := c.validateFns[key]
+	c.mutex.RUnlock()
+	if ok {
+		return validateFn, nil
+	}
+
+	// Check again, waiting for the in-flight build if there is one
+	c.mutex.Lock()
+	if validateFn, ok = c.validateFns[key]; ok {
+		c.mutex.Unlock()
+		return validateFn, nil
+	}
+	if inFlight, found := c.builds[key]; found {
+		c.mutex.Unlock()
+		<-inFlight.done
+		return inFlight.validateFn, inFlight.err
+	}
+	currentBuild := &validateFnBuild{done: make(chan struct{})}
+	c.builds[key] = currentBuild
+	c.mutex.Unlock()
+
+	// Build the validate function, caching it if it succeeds
+	defer func() {
+		c.mutex.Lock()
+		delete(c.builds, key)
+		if currentBuild.err == nil && currentBuild.validateFn != nil {
+			c.validateFns[key] = currentBuild.validateFn
+		}
+		c.mutex.Unlock()
+		close(currentBuild.done)
+	}()
+	currentBuild.validateFn, currentBuild.err = build()
+	return currentBuild.validateFn, currentBuild.err
+}
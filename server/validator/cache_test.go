@@ -0,0 +1,118 @@
+package validator
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	govalidatormappervalidation "github.com/ralvarezdev/go-validator/mapper/validation"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	testConcurrentCalls = 32
+)
+
+func TestValidateFnCacheSingleFlight(t *testing.T) {
+	cache := newValidateFnCache()
+	var builds atomic.Int32
+	release := make(chan struct{})
+
+	// Request the same key concurrently while the first build is blocked
+	var wg sync.WaitGroup
+	errs := make(chan error, testConcurrentCalls)
+	for range testConcurrentCalls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			validateFn, err := cache.get(
+				"key",
+				func() (ValidateFn, error) {
+					builds.Add(1)
+					<-release
+					return func(any) error {
+						return nil
+					}, nil
+				},
+			)
+			if err == nil && validateFn == nil {
+				err = errors.New("expected a validate function")
+			}
+			errs <- err
+		}()
+	}
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+	}
+	if count := builds.Load(); count != 1 {
+		t.Fatalf("expected a single build, got %d", count)
+	}
+}
+
+func TestValidateFnCacheFailedBuildIsNotCached(t *testing.T) {
+	cache := newValidateFnCache()
+	buildErr := errors.New("build failed")
+	if _, err := cache.get(
+		"key",
+		func() (ValidateFn, error) {
+			return nil, buildErr
+		},
+	); !errors.Is(err, buildErr) {
+		t.Fatalf("expected the build error, got %v", err)
+	}
+
+	// The next call builds again
+	builds := 0
+	if _, err := cache.get(
+		"key",
+		func() (ValidateFn, error) {
+			builds++
+			return func(any) error {
+				return nil
+			}, nil
+		},
+	); err != nil || builds != 1 {
+		t.Fatalf("expected the failed build to be retried, got %d builds (%v)", builds, err)
+	}
+}
+
+func TestServiceConcurrentFirstValidations(t *testing.T) {
+	service, err := NewService(nil, nil, ErrorFormatGRPC, nil)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+
+	// Validate the first requests of a request type concurrently, both with and without a method
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*testConcurrentCalls)
+	for range testConcurrentCalls {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			errs <- service.Validate(wrapperspb.String("value"))
+		}()
+		go func() {
+			defer wg.Done()
+			errs <- service.ValidateMethod(
+				"/pkg.Service/Method",
+				wrapperspb.String("value"),
+				func(*wrapperspb.StringValue, *govalidatormappervalidation.StructValidations) {},
+			)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err = range errs {
+		if err != nil {
+			t.Fatalf("Validate: %v", err)
+		}
+	}
+}
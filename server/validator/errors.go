@@ -2,6 +2,7 @@ package validator
 
 import (
 	"errors"
+	"fmt"
 )
 
 const (
@@ -9,9 +10,50 @@ const (
 	ErrFailedToAssertValidationsToBadRequest = "failed to assert validations to bad request: %v"
 	ErrFailedToValidateRequest = "failed to validate request: %v"
 	ErrFailedToCreateValidateFunction = "failed to create validate function: %v"
+	ErrURLSchemeNotAllowed = "URL scheme is not allowed: %s"
+	ErrURLHostNotAllowed = "URL host is not allowed: %s"
+	ErrUUIDVersionNotAllowed = "UUID version is not allowed: %d"
+	ErrIPVersionNotAllowed = "IP version is not allowed, must be IPv%d"
+	ErrCountryCodeNotAllowed = "country code is not allowed: %s"
+	ErrCurrencyCodeNotAllowed = "currency code is not allowed: %s"
+	ErrSlugMinimumLength = "slug must be at least %d characters long"
+	ErrSlugMaximumLength = "slug must be at most %d characters long"
+	ErrTimeRangeMinimumDuration = "time range must last at least %s"
+	ErrTimeRangeMaximumDuration = "time range must last at most %s"
 )
 
 var (
 	ErrNilValidator = errors.New("validator is nil")
 	ErrValidationsFailed = errors.New("validations failed")
+	ErrInvalidErrorFormat = errors.New("invalid validator error format")
+	ErrNilRequest = errors.New("request cannot be nil")
+	ErrNotProtoMessage = errors.New("request is not a protobuf message")
+	ErrInvalidBackend = errors.New("invalid validator backend")
+	ErrInvalidPhoneNumber = errors.New("invalid E.164 phone number")
+	ErrCallingCodeNotAllowed = errors.New("phone number calling code is not allowed")
+	ErrInvalidCallingCode = errors.New("invalid country calling code, must be an assigned one without the plus sign")
+	ErrInvalidURL = errors.New("invalid URL")
+	ErrInvalidUUID = errors.New("invalid UUID")
+	ErrInvalidIP = errors.New("invalid IP address")
+	ErrInvalidCIDR = errors.New("invalid CIDR")
+	ErrPrivateIPNotAllowed = errors.New("private IP addresses are not allowed")
+	ErrLoopbackIPNotAllowed = errors.New("loopback IP addresses are not allowed")
+	ErrInvalidCountryCode = errors.New("invalid ISO 3166-1 alpha-2 country code")
+	ErrInvalidCurrencyCode = errors.New("invalid ISO 4217 currency code")
+	ErrInvalidSlug = errors.New("invalid slug, must be lowercase alphanumeric words separated by hyphens")
+	ErrInvalidTimeRange = errors.New("time range end must be after its start")
+	ErrNilServiceInfoProvider = errors.New("service info provider cannot be nil")
 )
+
+// NewInvalidCallingCodeError creates a new invalid calling code error
+//
+// Parameters:
+//
+//   - callingCode: the invalid calling code
+//
+// Returns:
+//
+//   - error: the invalid calling code error
+func NewInvalidCallingCodeError(callingCode string) error {
+	return fmt.Errorf("%w: %q", ErrInvalidCallingCode, callingCode)
+}
@@ -0,0 +1,401 @@
+package validator
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	govalidatormappervalidation "github.com/ralvarezdev/go-validator/mapper/validation"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+)
+
+// checkFieldOptions checks the default options of the field validators
+//
+// Parameters:
+//
+//   - fieldOptions: the default options of the field validators (optional, can be nil)
+//
+// Returns:
+//
+//   - error: if any allowed calling code is not an assigned country calling code
+func checkFieldOptions(fieldOptions *FieldOptions) error {
+	if fieldOptions == nil || fieldOptions.PhoneNumber == nil {
+		return nil
+	}
+	for _, callingCode := range fieldOptions.PhoneNumber.AllowedCallingCodes {
+		if !slices.Contains(CallingCodes, callingCode) {
+			return NewInvalidCallingCodeError(callingCode)
+		}
+	}
+	return nil
+}
+
+// PhoneNumber validates an E.164 phone number field
+//
+// Parameters:
+//
+//   - phoneNumberField: the name of the phone number field
+//   - phoneNumber: the phone number to validate
+//   - validations: the struct validations
+func (d DefaultService) PhoneNumber(
+	phoneNumberField string,
+	phoneNumber string,
+	validations *govalidatormappervalidation.StructValidations,
+) {
+	// Check if the phone number is in the E.164 format
+	if !E164Regex.MatchString(phoneNumber) {
+		validations.AddFieldValidationError(
+			phoneNumberField,
+			ErrInvalidPhoneNumber,
+		)
+		return
+	}
+
+	// Check if the calling code is allowed
+	if d.fieldOptions == nil || d.fieldOptions.PhoneNumber == nil {
+		return
+	}
+	allowedCallingCodes := d.fieldOptions.PhoneNumber.AllowedCallingCodes
+	if len(allowedCallingCodes) == 0 {
+		return
+	}
+	// The allowed calling codes are checked to be assigned ones, so the phone number can only start with its own
+	digits := phoneNumber[1:]
+	for _, callingCode := range allowedCallingCodes {
+		if strings.HasPrefix(digits, callingCode) {
+			return
+		}
+	}
+	validations.AddFieldValidationError(
+		phoneNumberField,
+		ErrCallingCodeNotAllowed,
+	)
+}
+
+// URL validates an absolute URL field
+//
+// Parameters:
+//
+//   - urlField: the name of the URL field
+//   - rawURL: the URL to validate
+//   - validations: the struct validations
+func (d DefaultService) URL(
+	urlField string,
+	rawURL string,
+	validations *govalidatormappervalidation.StructValidations,
+) {
+	// Check if the URL is absolute
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil || parsedURL.Scheme == "" || parsedURL.Hostname() == "" {
+		validations.AddFieldValidationError(urlField, ErrInvalidURL)
+		return
+	}
+
+	// Check if the scheme is allowed
+	allowedSchemes := DefaultURLSchemes
+	var allowedHosts []string
+	if d.fieldOptions != nil && d.fieldOptions.URL != nil {
+		if len(d.fieldOptions.URL.AllowedSchemes) > 0 {
+			allowedSchemes = d.fieldOptions.URL.AllowedSchemes
+		}
+		allowedHosts = d.fieldOptions.URL.AllowedHosts
+	}
+	scheme := strings.ToLower(parsedURL.Scheme)
+	if !slices.ContainsFunc(
+		allowedSchemes, func(allowedScheme string) bool {
+			return strings.EqualFold(allowedScheme, scheme)
+		},
+	) {
+		validations.AddFieldValidationError(
+			urlField,
+			fmt.Errorf(ErrURLSchemeNotAllowed, scheme),
+		)
+	}
+
+	// Check if the host is allowed
+	host := parsedURL.Hostname()
+	if len(allowedHosts) > 0 && !slices.ContainsFunc(
+		allowedHosts, func(allowedHost string) bool {
+			return strings.EqualFold(allowedHost, host)
+		},
+	) {
+		validations.AddFieldValidationError(
+			urlField,
+			fmt.Errorf(ErrURLHostNotAllowed, host),
+		)
+	}
+}
+
+// UUID validates a UUID field in its canonical form
+//
+// Parameters:
+//
+//   - uuidField: the name of the UUID field
+//   - uuid: the UUID to validate
+//   - validations: the struct validations
+func (d DefaultService) UUID(
+	uuidField string,
+	uuid string,
+	validations *govalidatormappervalidation.StructValidations,
+) {
+	// Check if the UUID is in its canonical form
+	if !UUIDRegex.MatchString(uuid) {
+		validations.AddFieldValidationError(uuidField, ErrInvalidUUID)
+		return
+	}
+
+	// Check if the version is allowed
+	if d.fieldOptions == nil || d.fieldOptions.UUID == nil || len(d.fieldOptions.UUID.Versions) == 0 {
+		return
+	}
+	version, err := strconv.ParseInt(uuid[14:15], 16, 0)
+	if err != nil {
+		validations.AddFieldValidationError(uuidField, ErrInvalidUUID)
+		return
+	}
+	if !slices.Contains(d.fieldOptions.UUID.Versions, int(version)) {
+		validations.AddFieldValidationError(
+			uuidField,
+			fmt.Errorf(ErrUUIDVersionNotAllowed, version),
+		)
+	}
+}
+
+// validateIPAddr validates an IP address against the IP options
+//
+// Parameters:
+//
+//   - field: the name of the field
+//   - addr: the IP address to validate
+//   - validations: the struct validations
+func (d DefaultService) validateIPAddr(
+	field string,
+	addr netip.Addr,
+	validations *govalidatormappervalidation.StructValidations,
+) {
+	if d.fieldOptions == nil || d.fieldOptions.IP == nil {
+		return
+	}
+	options := d.fieldOptions.IP
+
+	// Check if the IP version is allowed
+	addr = addr.Unmap()
+	if (options.Version == IPVersion4 && !addr.Is4()) || (options.Version == IPVersion6 && !addr.Is6()) {
+		validations.AddFieldValidationError(
+			field,
+			fmt.Errorf(ErrIPVersionNotAllowed, options.Version),
+		)
+	}
+
+	// Check if the private and loopback addresses are allowed
+	if options.DenyPrivate && addr.IsPrivate() {
+		validations.AddFieldValidationError(field, ErrPrivateIPNotAllowed)
+	}
+	if options.DenyLoopback && addr.IsLoopback() {
+		validations.AddFieldValidationError(field, ErrLoopbackIPNotAllowed)
+	}
+}
+
+// IP validates an IP address field
+//
+// Parameters:
+//
+//   - ipField: the name of the IP address field
+//   - ip: the IP address to validate
+//   - validations: the struct validations
+func (d DefaultService) IP(
+	ipField string,
+	ip string,
+	validations *govalidatormappervalidation.StructValidations,
+) {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		validations.AddFieldValidationError(ipField, ErrInvalidIP)
+		return
+	}
+	d.validateIPAddr(ipField, addr, validations)
+}
+
+// CIDR validates a CIDR field
+//
+// Parameters:
+//
+//   - cidrField: the name of the CIDR field
+//   - cidr: the CIDR to validate
+//   - validations: the struct validations
+func (d DefaultService) CIDR(
+	cidrField string,
+	cidr string,
+	validations *govalidatormappervalidation.StructValidations,
+) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		validations.AddFieldValidationError(cidrField, ErrInvalidCIDR)
+		return
+	}
+	d.validateIPAddr(cidrField, prefix.Addr(), validations)
+}
+
+// CountryCode validates an ISO 3166-1 alpha-2 country code field
+//
+// Parameters:
+//
+//   - countryCodeField: the name of the country code field
+//   - countryCode: the country code to validate
+//   - validations: the struct validations
+func (d DefaultService) CountryCode(
+	countryCodeField string,
+	countryCode string,
+	validations *govalidatormappervalidation.StructValidations,
+) {
+	// Check if the country code is an uppercase ISO 3166-1 alpha-2 code
+	if len(countryCode) != 2 || strings.ToUpper(countryCode) != countryCode {
+		validations.AddFieldValidationError(
+			countryCodeField,
+			ErrInvalidCountryCode,
+		)
+		return
+	}
+	region, err := language.ParseRegion(countryCode)
+	if err != nil || !region.IsCountry() || region.String() != countryCode {
+		validations.AddFieldValidationError(
+			countryCodeField,
+			ErrInvalidCountryCode,
+		)
+		return
+	}
+
+	// Check if the country code is allowed
+	if d.fieldOptions == nil || d.fieldOptions.CountryCode == nil || len(d.fieldOptions.CountryCode.AllowedCodes) == 0 {
+		return
+	}
+	if !slices.Contains(d.fieldOptions.CountryCode.AllowedCodes, countryCode) {
+		validations.AddFieldValidationError(
+			countryCodeField,
+			fmt.Errorf(ErrCountryCodeNotAllowed, countryCode),
+		)
+	}
+}
+
+// CurrencyCode validates an ISO 4217 currency code field
+//
+// Parameters:
+//
+//   - currencyCodeField: the name of the currency code field
+//   - currencyCode: the currency code to validate
+//   - validations: the struct validations
+func (d DefaultService) CurrencyCode(
+	currencyCodeField string,
+	currencyCode string,
+	validations *govalidatormappervalidation.StructValidations,
+) {
+	// Check if the currency code is an uppercase ISO 4217 code
+	if len(currencyCode) != 3 || strings.ToUpper(currencyCode) != currencyCode {
+		validations.AddFieldValidationError(
+			currencyCodeField,
+			ErrInvalidCurrencyCode,
+		)
+		return
+	}
+	if _, err := currency.ParseISO(currencyCode); err != nil {
+		validations.AddFieldValidationError(
+			currencyCodeField,
+			ErrInvalidCurrencyCode,
+		)
+		return
+	}
+
+	// Check if the currency code is allowed
+	if d.fieldOptions == nil || d.fieldOptions.CurrencyCode == nil || len(d.fieldOptions.CurrencyCode.AllowedCodes) == 0 {
+		return
+	}
+	if !slices.Contains(d.fieldOptions.CurrencyCode.AllowedCodes, currencyCode) {
+		validations.AddFieldValidationError(
+			currencyCodeField,
+			fmt.Errorf(ErrCurrencyCodeNotAllowed, currencyCode),
+		)
+	}
+}
+
+// Slug validates a slug field
+//
+// Parameters:
+//
+//   - slugField: the name of the slug field
+//   - slug: the slug to validate
+//   - validations: the struct validations
+func (d DefaultService) Slug(
+	slugField string,
+	slug string,
+	validations *govalidatormappervalidation.StructValidations,
+) {
+	// Check if the slug has lowercase alphanumeric words separated by single hyphens
+	if !SlugRegex.MatchString(slug) {
+		validations.AddFieldValidationError(slugField, ErrInvalidSlug)
+		return
+	}
+
+	// Check the slug length
+	if d.fieldOptions == nil || d.fieldOptions.Slug == nil {
+		return
+	}
+	options := d.fieldOptions.Slug
+	if options.MinimumLength > 0 && len(slug) < options.MinimumLength {
+		validations.AddFieldValidationError(
+			slugField,
+			fmt.Errorf(ErrSlugMinimumLength, options.MinimumLength),
+		)
+	}
+	if options.MaximumLength > 0 && len(slug) > options.MaximumLength {
+		validations.AddFieldValidationError(
+			slugField,
+			fmt.Errorf(ErrSlugMaximumLength, options.MaximumLength),
+		)
+	}
+}
+
+// TimeRange validates a time range, recording the validation errors in the end field
+//
+// Parameters:
+//
+//   - endField: the name of the end field of the range
+//   - start: the start of the range
+//   - end: the end of the range
+//   - validations: the struct validations
+func (d DefaultService) TimeRange(
+	endField string,
+	start time.Time,
+	end time.Time,
+	validations *govalidatormappervalidation.StructValidations,
+) {
+	var options TimeRangeOptions
+	if d.fieldOptions != nil && d.fieldOptions.TimeRange != nil {
+		options = *d.fieldOptions.TimeRange
+	}
+
+	// Check if the end is after the start
+	if end.Before(start) || (end.Equal(start) && !options.AllowEqual) {
+		validations.AddFieldValidationError(endField, ErrInvalidTimeRange)
+		return
+	}
+
+	// Check the range duration
+	duration := end.Sub(start)
+	if options.MinimumDuration > 0 && duration < options.MinimumDuration {
+		validations.AddFieldValidationError(
+			endField,
+			fmt.Errorf(ErrTimeRangeMinimumDuration, options.MinimumDuration),
+		)
+	}
+	if options.MaximumDuration > 0 && duration > options.MaximumDuration {
+		validations.AddFieldValidationError(
+			endField,
+			fmt.Errorf(ErrTimeRangeMaximumDuration, options.MaximumDuration),
+		)
+	}
+}
@@ -0,0 +1,328 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	govalidatormappervalidation "github.com/ralvarezdev/go-validator/mapper/validation"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	testField = "field"
+)
+
+var (
+	_ FieldService = DefaultService{}
+	_ FieldService = ProtovalidateService{}
+)
+
+// newTestFieldService creates a validator service with the given field options
+func newTestFieldService(t *testing.T, fieldOptions *FieldOptions) FieldService {
+	t.Helper()
+
+	service, err := NewService(nil, nil, ErrorFormatGRPC, nil, WithFieldOptions(fieldOptions))
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	return service
+}
+
+// getFieldErrors runs the given field validator and returns the errors added to the test field
+func getFieldErrors(
+	t *testing.T,
+	service FieldService,
+	validate func(FieldService, *govalidatormappervalidation.StructValidations),
+) []error {
+	t.Helper()
+
+	validations, err := govalidatormappervalidation.NewStructValidations(wrapperspb.String(""))
+	if err != nil {
+		t.Fatalf("NewStructValidations: %v", err)
+	}
+	validate(service, validations)
+	fieldValidations, ok := validations.GetFieldsValidations()[testField]
+	if !ok {
+		return nil
+	}
+	return fieldValidations.GetErrors()
+}
+
+func TestFieldValidators(t *testing.T) {
+	start := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		fieldOptions *FieldOptions
+		validate     func(FieldService, *govalidatormappervalidation.StructValidations)
+		expected     []error
+	}{
+		{
+			name: "valid phone number",
+			validate: func(s FieldService, v *govalidatormappervalidation.StructValidations) {
+				s.PhoneNumber(testField, "+34600000000", v)
+			},
+		},
+		{
+			name: "invalid phone number",
+			validate: func(s FieldService, v *govalidatormappervalidation.StructValidations) {
+				s.PhoneNumber(testField, "600000000", v)
+			},
+			expected: []error{ErrInvalidPhoneNumber},
+		},
+		{
+			name:         "phone number calling code not allowed",
+			fieldOptions: &FieldOptions{PhoneNumber: &PhoneNumberOptions{AllowedCallingCodes: []string{"1"}}},
+			validate: func(s FieldService, v *govalidatormappervalidation.StructValidations) {
+				s.PhoneNumber(testField, "+34600000000", v)
+			},
+			expected: []error{ErrCallingCodeNotAllowed},
+		},
+		{
+			name:         "phone number calling code allowed",
+			fieldOptions: &FieldOptions{PhoneNumber: &PhoneNumberOptions{AllowedCallingCodes: []string{"1", "34"}}},
+			validate: func(s FieldService, v *govalidatormappervalidation.StructValidations) {
+				s.PhoneNumber(testField, "+34600000000", v)
+			},
+		},
+		{
+			name:         "phone number with a calling code sharing the first digit",
+			fieldOptions: &FieldOptions{PhoneNumber: &PhoneNumberOptions{AllowedCallingCodes: []string{"30"}}},
+			validate: func(s FieldService, v *govalidatormappervalidation.StructValidations) {
+				s.PhoneNumber(testField, "+34600000000", v)
+			},
+			expected: []error{ErrCallingCodeNotAllowed},
+		},
+		{
+			name: "valid URL",
+			validate: func(s FieldService, v *govalidatormappervalidation.StructValidations) {
+				s.URL(testField, "https://example.com/path", v)
+			},
+		},
+		{
+			name: "relative URL",
+			validate: func(s FieldService, v *govalidatormappervalidation.StructValidations) {
+				s.URL(testField, "/path", v)
+			},
+			expected: []error{ErrInvalidURL},
+		},
+		{
+			name: "URL scheme not allowed by default",
+			validate: func(s FieldService, v *govalidatormappervalidation.StructValidations) {
+				s.URL(testField, "ftp://example.com", v)
+			},
+			expected: []error{fmt.Errorf(ErrURLSchemeNotAllowed, "ftp")},
+		},
+		{
+			name:         "URL host not allowed",
+			fieldOptions: &FieldOptions{URL: &URLOptions{AllowedHosts: []string{"example.com"}}},
+			validate: func(s FieldService, v *govalidatormappervalidation.StructValidations) {
+				s.URL(testField, "https://other.com", v)
+			},
+			expected: []error{fmt.Errorf(ErrURLHostNotAllowed, "other.com")},
+		},
+		{
+			name: "invalid UUID",
+			validate: func(s FieldService, v *govalidatormappervalidation.StructValidations) {
+				s.UUID(testField, "not-a-uuid", v)
+			},
+			expected: []error{ErrInvalidUUID},
+		},
+		{
+			name:         "UUID version not allowed",
+			fieldOptions: &FieldOptions{UUID: &UUIDOptions{Versions: []int{7}}},
+			validate: func(s FieldService, v *govalidatormappervalidation.StructValidations) {
+				s.UUID(testField, "123e4567-e89b-42d3-a456-426614174000", v)
+			},
+			expected: []error{fmt.Errorf(ErrUUIDVersionNotAllowed, 4)},
+		},
+		{
+			name: "invalid IP",
+			validate: func(s FieldService, v *govalidatormappervalidation.StructValidations) {
+				s.IP(testField, "256.0.0.1", v)
+			},
+			expected: []error{ErrInvalidIP},
+		},
+		{
+			name: "IP version, private and loopback not allowed",
+			fieldOptions: &FieldOptions{
+				IP: &IPOptions{Version: IPVersion6, DenyPrivate: true, DenyLoopback: true},
+			},
+			validate: func(s FieldService, v *govalidatormappervalidation.StructValidations) {
+				s.IP(testField, "127.0.0.1", v)
+			},
+			expected: []error{fmt.Errorf(ErrIPVersionNotAllowed, IPVersion6), ErrLoopbackIPNotAllowed},
+		},
+		{
+			name:         "private CIDR not allowed",
+			fieldOptions: &FieldOptions{IP: &IPOptions{DenyPrivate: true}},
+			validate: func(s FieldService, v *govalidatormappervalidation.StructValidations) {
+				s.CIDR(testField, "10.0.0.0/8", v)
+			},
+			expected: []error{ErrPrivateIPNotAllowed},
+		},
+		{
+			name: "invalid CIDR",
+			validate: func(s FieldService, v *govalidatormappervalidation.StructValidations) {
+				s.CIDR(testField, "10.0.0.0", v)
+			},
+			expected: []error{ErrInvalidCIDR},
+		},
+		{
+			name: "lowercase country code",
+			validate: func(s FieldService, v *govalidatormappervalidation.StructValidations) {
+				s.CountryCode(testField, "es", v)
+			},
+			expected: []error{ErrInvalidCountryCode},
+		},
+		{
+			name:         "country code not allowed",
+			fieldOptions: &FieldOptions{CountryCode: &CountryCodeOptions{AllowedCodes: []string{"US"}}},
+			validate: func(s FieldService, v *govalidatormappervalidation.StructValidations) {
+				s.CountryCode(testField, "ES", v)
+			},
+			expected: []error{fmt.Errorf(ErrCountryCodeNotAllowed, "ES")},
+		},
+		{
+			name: "unknown currency code",
+			validate: func(s FieldService, v *govalidatormappervalidation.StructValidations) {
+				s.CurrencyCode(testField, "XYZ", v)
+			},
+			expected: []error{ErrInvalidCurrencyCode},
+		},
+		{
+			name:         "currency code not allowed",
+			fieldOptions: &FieldOptions{CurrencyCode: &CurrencyCodeOptions{AllowedCodes: []string{"USD"}}},
+			validate: func(s FieldService, v *govalidatormappervalidation.StructValidations) {
+				s.CurrencyCode(testField, "EUR", v)
+			},
+			expected: []error{fmt.Errorf(ErrCurrencyCodeNotAllowed, "EUR")},
+		},
+		{
+			name: "valid slug",
+			validate: func(s FieldService, v *govalidatormappervalidation.StructValidations) {
+				s.Slug(testField, "my-slug-1", v)
+			},
+		},
+		{
+			name:         "invalid slug is not length checked",
+			fieldOptions: &FieldOptions{Slug: &SlugOptions{MinimumLength: 10}},
+			validate: func(s FieldService, v *govalidatormappervalidation.StructValidations) {
+				s.Slug(testField, "Bad--", v)
+			},
+			expected: []error{ErrInvalidSlug},
+		},
+		{
+			name:         "slug too short",
+			fieldOptions: &FieldOptions{Slug: &SlugOptions{MinimumLength: 10}},
+			validate: func(s FieldService, v *govalidatormappervalidation.StructValidations) {
+				s.Slug(testField, "short", v)
+			},
+			expected: []error{fmt.Errorf(ErrSlugMinimumLength, 10)},
+		},
+		{
+			name: "time range end before start",
+			validate: func(s FieldService, v *govalidatormappervalidation.StructValidations) {
+				s.TimeRange(testField, start, start.Add(-time.Hour), v)
+			},
+			expected: []error{ErrInvalidTimeRange},
+		},
+		{
+			name:         "equal time range allowed",
+			fieldOptions: &FieldOptions{TimeRange: &TimeRangeOptions{AllowEqual: true}},
+			validate: func(s FieldService, v *govalidatormappervalidation.StructValidations) {
+				s.TimeRange(testField, start, start, v)
+			},
+		},
+		{
+			name:         "time range too long",
+			fieldOptions: &FieldOptions{TimeRange: &TimeRangeOptions{MaximumDuration: time.Hour}},
+			validate: func(s FieldService, v *govalidatormappervalidation.StructValidations) {
+				s.TimeRange(testField, start, start.Add(2*time.Hour), v)
+			},
+			expected: []error{fmt.Errorf(ErrTimeRangeMaximumDuration, time.Hour)},
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				service := newTestFieldService(t, test.fieldOptions)
+				errs := getFieldErrors(t, service, test.validate)
+				if len(errs) != len(test.expected) {
+					t.Fatalf("expected %v, got %v", test.expected, errs)
+				}
+				for index, expected := range test.expected {
+					if !errors.Is(errs[index], expected) && errs[index].Error() != expected.Error() {
+						t.Fatalf("expected %v, got %v", expected, errs[index])
+					}
+				}
+			},
+		)
+	}
+}
+
+func TestBackendServiceFieldOptions(t *testing.T) {
+	for _, backend := range []Backend{BackendGoValidator, BackendProtovalidate} {
+		t.Run(
+			string(backend), func(t *testing.T) {
+				service, err := NewBackendService(
+					backend,
+					nil,
+					nil,
+					ErrorFormatGRPC,
+					nil,
+					WithFieldOptions(&FieldOptions{Slug: &SlugOptions{MaximumLength: 3}}),
+				)
+				if err != nil {
+					t.Fatalf("NewBackendService: %v", err)
+				}
+				errs := getFieldErrors(
+					t,
+					service,
+					func(s FieldService, v *govalidatormappervalidation.StructValidations) {
+						s.Slug(testField, "long-slug", v)
+					},
+				)
+				if len(errs) != 1 || errs[0].Error() != fmt.Sprintf(ErrSlugMaximumLength, 3) {
+					t.Fatalf("expected the slug maximum length error, got %v", errs)
+				}
+			},
+		)
+	}
+}
+
+func TestNewServiceInvalidCallingCodes(t *testing.T) {
+	for _, callingCode := range []string{"+34", "3", "0", "", "999", "34a"} {
+		t.Run(
+			callingCode, func(t *testing.T) {
+				_, err := NewBackendService(
+					BackendGoValidator,
+					nil,
+					nil,
+					ErrorFormatGRPC,
+					nil,
+					WithFieldOptions(
+						&FieldOptions{
+							PhoneNumber: &PhoneNumberOptions{AllowedCallingCodes: []string{"1", callingCode}},
+						},
+					),
+				)
+				if !errors.Is(err, ErrInvalidCallingCode) {
+					t.Fatalf("expected ErrInvalidCallingCode, got %v", err)
+				}
+			},
+		)
+	}
+}
+
+func TestCallingCodesArePrefixFree(t *testing.T) {
+	for _, callingCode := range CallingCodes {
+		for _, otherCallingCode := range CallingCodes {
+			if callingCode != otherCallingCode && strings.HasPrefix(otherCallingCode, callingCode) {
+				t.Fatalf("expected %q not to be a prefix of %q", callingCode, otherCallingCode)
+			}
+		}
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"time"
 
 	"github.com/ralvarezdev/go-validator/mapper/validation"
+	"google.golang.org/grpc"
+
+	gogrpc "github.com/ralvarezdev/go-grpc"
 )
 
 type (
@@ -39,4 +42,93 @@ type (
 			auxiliaryValidatorFns ...any,
 		) error
 	}
+
+	// FieldService is the interface for the validator services with the field validators of the phone numbers, URLs,
+	// UUIDs, IP addresses, CIDRs, country and currency codes, slugs and time ranges
+	FieldService interface {
+		PhoneNumber(
+			phoneNumberField string,
+			phoneNumber string,
+			validations *validation.StructValidations,
+		)
+		URL(
+			urlField string,
+			rawURL string,
+			validations *validation.StructValidations,
+		)
+		UUID(
+			uuidField string,
+			uuid string,
+			validations *validation.StructValidations,
+		)
+		IP(
+			ipField string,
+			ip string,
+			validations *validation.StructValidations,
+		)
+		CIDR(
+			cidrField string,
+			cidr string,
+			validations *validation.StructValidations,
+		)
+		CountryCode(
+			countryCodeField string,
+			countryCode string,
+			validations *validation.StructValidations,
+		)
+		CurrencyCode(
+			currencyCodeField string,
+			currencyCode string,
+			validations *validation.StructValidations,
+		)
+		Slug(
+			slugField string,
+			slug string,
+			validations *validation.StructValidations,
+		)
+		TimeRange(
+			endField string,
+			start time.Time,
+			end time.Time,
+			validations *validation.StructValidations,
+		)
+	}
+
+	// MethodService is the interface for the validator services that cache the validate functions of each method, so
+	// the auxiliary validator functions of a method are not shared with the other methods with the same request type
+	MethodService interface {
+		CreateMethodValidateFn(
+			fullMethod string,
+			requestExample any,
+			auxiliaryValidatorFns ...any,
+		) (ValidateFn, error)
+		ValidateMethod(
+			fullMethod string,
+			request any,
+			auxiliaryValidatorFns ...any,
+		) error
+	}
+
+	// WarmupService is the interface for the validator services that can precompile the validate functions of every
+	// registered method at startup
+	WarmupService interface {
+		Warmup(
+			serviceInfoProvider ServiceInfoProvider,
+			auxiliaryValidatorFns *gogrpc.MethodMatcher[[]any],
+		) error
+	}
+
+	// BackendService is the interface for the validator services created by NewBackendService, with the field
+	// validators, the validate functions cached by method and the warm-up
+	BackendService interface {
+		Service
+		FieldService
+		MethodService
+		WarmupService
+	}
+
+	// ServiceInfoProvider is the interface for the providers of the registered services, e.g. the *grpc.Server
+	ServiceInfoProvider interface {
+		GetServiceInfo() map[string]grpc.ServiceInfo
+	}
 )
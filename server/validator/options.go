@@ -0,0 +1,58 @@
+package validator
+
+import (
+	"buf.build/go/protovalidate"
+)
+
+// newOptions creates the optional settings of the validator services from the given options
+//
+// Parameters:
+//
+//   - options: the options to apply
+//
+// Returns:
+//
+//   - *Options: the optional settings
+func newOptions(options ...Option) *Options {
+	o := &Options{}
+	for _, option := range options {
+		if option != nil {
+			option(o)
+		}
+	}
+	return o
+}
+
+// WithFieldOptions sets the default options of the field validators of the FieldService
+//
+// Parameters:
+//
+//   - fieldOptions: the default options of the field validators
+//
+// Returns:
+//
+//   - Option: the option
+func WithFieldOptions(fieldOptions *FieldOptions) Option {
+	return func(options *Options) {
+		options.FieldOptions = fieldOptions
+	}
+}
+
+// WithProtovalidateOptions sets the protovalidate validator options, e.g. the CEL and custom rule options, used by
+// the protovalidate backend
+//
+// Parameters:
+//
+//   - validatorOptions: the protovalidate validator options
+//
+// Returns:
+//
+//   - Option: the option
+func WithProtovalidateOptions(validatorOptions ...protovalidate.ValidatorOption) Option {
+	return func(options *Options) {
+		options.ProtovalidateOptions = append(
+			options.ProtovalidateOptions,
+			validatorOptions...,
+		)
+	}
+}
@@ -0,0 +1,418 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"buf.build/go/protovalidate"
+	"connectrpc.com/connect"
+	goreflect "github.com/ralvarezdev/go-reflect"
+	govalidatormappervalidation "github.com/ralvarezdev/go-validator/mapper/validation"
+	govalidatormappervalidator "github.com/ralvarezdev/go-validator/mapper/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+
+	gogrpc "github.com/ralvarezdev/go-grpc"
+)
+
+type (
+	// ProtovalidateService is the validator service that evaluates the protovalidate (buf.validate) constraints of
+	// the requests, including the CEL rules. The auxiliary validator functions and the field helpers work as in the
+	// DefaultService
+	ProtovalidateService struct {
+		*DefaultService
+		validator   protovalidate.Validator
+		validateFns *validateFnCache
+	}
+)
+
+// NewProtovalidateService creates a new protovalidate validator service
+//
+// Parameters:
+//
+//   - birthdateOptions: the default birthdate options (optional, can be nil)
+//   - passwordOptions: the default password options (optional, can be nil)
+//   - errorFormat: the form of the returned errors, either connect errors or gRPC status errors (optional, defaults
+//     to connect errors)
+//   - logger: the logger
+//   - options: the optional settings, e.g. WithFieldOptions or WithProtovalidateOptions
+//
+// Returns:
+//
+//   - *ProtovalidateService: the validator service
+//   - error: if there was an error creating the validator service
+func NewProtovalidateService(
+	birthdateOptions *govalidatormappervalidator.BirthdateOptions,
+	passwordOptions *govalidatormappervalidator.PasswordOptions,
+	errorFormat ErrorFormat,
+	logger *slog.Logger,
+	options ...Option,
+) (*ProtovalidateService, error) {
+	// Create the default service for the field helpers and the auxiliary validator functions
+	defaultService, err := NewService(
+		birthdateOptions,
+		passwordOptions,
+		errorFormat,
+		logger,
+		options...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the protovalidate validator
+	validator, err := protovalidate.New(newOptions(options...).ProtovalidateOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProtovalidateService{
+		DefaultService: defaultService,
+		validator:      validator,
+		validateFns:    newValidateFnCache(),
+	}, nil
+}
+
+// NewFieldViolations converts the violations of a protovalidate validation error to bad request field violations
+//
+// Parameters:
+//
+//   - validationErr: the protovalidate validation error
+//
+// Returns:
+//
+//   - []*errdetails.BadRequest_FieldViolation: the field violations
+func NewFieldViolations(
+	validationErr *protovalidate.ValidationError,
+) []*errdetails.BadRequest_FieldViolation {
+	if validationErr == nil {
+		return nil
+	}
+
+	fieldViolations := make(
+		[]*errdetails.BadRequest_FieldViolation,
+		0,
+		len(validationErr.Violations),
+	)
+	for _, violation := range validationErr.Violations {
+		if violation == nil || violation.Proto == nil {
+			continue
+		}
+		fieldViolations = append(
+			fieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       protovalidate.FieldPathString(violation.Proto.GetField()),
+				Description: violation.Proto.GetMessage(),
+				Reason:      violation.Proto.GetRuleId(),
+			},
+		)
+	}
+	return fieldViolations
+}
+
+// runAuxiliaryValidatorFns runs the auxiliary validator functions on a request
+//
+// Parameters:
+//
+//   - request: the request to validate
+//   - auxiliaryValidatorFns: auxiliary validator functions to use in the validation
+//
+// Returns:
+//
+//   - []*errdetails.BadRequest_FieldViolation: the field violations of the failed validations
+//   - error: if there was an error running the auxiliary validator functions
+func (p ProtovalidateService) runAuxiliaryValidatorFns(
+	request any,
+	auxiliaryValidatorFns ...any,
+) ([]*errdetails.BadRequest_FieldViolation, error) {
+	if len(auxiliaryValidatorFns) == 0 {
+		return nil, nil
+	}
+
+	// Initialize the struct validations of the request
+	rootStructValidations, err := govalidatormappervalidation.NewStructValidations(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call the auxiliary validator functions
+	for _, auxiliaryValidatorFn := range auxiliaryValidatorFns {
+		if _, err = goreflect.SafeCallFunction(
+			auxiliaryValidatorFn,
+			request,
+			rootStructValidations,
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	// Parse the validations
+	validations, err := p.service.ParseValidations(rootStructValidations)
+	if err != nil || validations == nil {
+		return nil, err
+	}
+	badRequest, ok := validations.(*errdetails.BadRequest)
+	if !ok {
+		return nil, ErrValidationsFailed
+	}
+	return badRequest.GetFieldViolations(), nil
+}
+
+// buildProtovalidateFn builds a validate function for a given request type
+//
+// Parameters:
+//
+//   - requestType: the name of the request type
+//   - auxiliaryValidatorFns: auxiliary validator functions to use in the validation
+//
+// Returns:
+//
+//   - ValidateFn: the validate function
+func (p ProtovalidateService) buildProtovalidateFn(
+	requestType string,
+	auxiliaryValidatorFns ...any,
+) ValidateFn {
+	return func(request any) error {
+		message, ok := request.(proto.Message)
+		if !ok {
+			return p.formatError(
+				connect.NewError(
+					connect.CodeInternal,
+					fmt.Errorf(ErrFailedToValidateRequest, requestType),
+				),
+			)
+		}
+
+		// Evaluate the protovalidate constraints
+		var fieldViolations []*errdetails.BadRequest_FieldViolation
+		if err := p.validator.Validate(message); err != nil {
+			var validationErr *protovalidate.ValidationError
+			if !errors.As(err, &validationErr) {
+				if p.logger != nil {
+					p.logger.Error(
+						"Failed to evaluate protovalidate constraints",
+						slog.String("type", requestType),
+						slog.String("error", err.Error()),
+					)
+				}
+				return p.formatError(
+					connect.NewError(
+						connect.CodeInternal,
+						fmt.Errorf(ErrFailedToValidateRequest, requestType),
+					),
+				)
+			}
+			fieldViolations = NewFieldViolations(validationErr)
+		}
+
+		// Run the auxiliary validator functions
+		auxiliaryFieldViolations, err := p.runAuxiliaryValidatorFns(
+			request,
+			auxiliaryValidatorFns...,
+		)
+		if err != nil {
+			if p.logger != nil {
+				p.logger.Error(
+					"Failed to run auxiliary validator functions",
+					slog.String("type", requestType),
+					slog.String("error", err.Error()),
+				)
+			}
+			return p.formatError(
+				connect.NewError(
+					connect.CodeInternal,
+					fmt.Errorf(ErrFailedToValidateRequest, requestType),
+				),
+			)
+		}
+		fieldViolations = append(fieldViolations, auxiliaryFieldViolations...)
+
+		// Check if there are no violations
+		if len(fieldViolations) == 0 {
+			return nil
+		}
+		return p.newValidationsError(
+			&errdetails.BadRequest{
+				FieldViolations: fieldViolations,
+			},
+		)
+	}
+}
+
+// CreateValidateFn creates a validate function for a given request example, which must be a protobuf message. The
+// cached validate functions are keyed by the request type and built only once, even if they are requested
+// concurrently. The validate functions with auxiliary validator functions are not cached, since those are specific
+// to a method, use CreateMethodValidateFn instead
+//
+// Parameters:
+//
+//   - requestExample: an example of the request to validate
+//   - cache: whether to cache the validate function or not
+//   - auxiliaryValidatorFns: auxiliary validator functions to use in the validation
+//
+// Returns:
+//
+//   - ValidateFn: the validate function
+//   - error: if the request example is not a protobuf message
+func (p ProtovalidateService) CreateValidateFn(
+	requestExample any,
+	cache bool,
+	auxiliaryValidatorFns ...any,
+) (ValidateFn, error) {
+	return p.createValidateFn(
+		"",
+		requestExample,
+		cache && len(auxiliaryValidatorFns) == 0,
+		auxiliaryValidatorFns...,
+	)
+}
+
+// CreateMethodValidateFn creates and caches a validate function for the request of a given method, which must be a
+// protobuf message. The validate functions with auxiliary validator functions are keyed by the method and the
+// request type, so they are not shared with the other methods with the same request type
+//
+// Parameters:
+//
+//   - fullMethod: the full method name
+//   - requestExample: an example of the request to validate
+//   - auxiliaryValidatorFns: auxiliary validator functions of the method to use in the validation
+//
+// Returns:
+//
+//   - ValidateFn: the validate function
+//   - error: if the request example is not a protobuf message
+func (p ProtovalidateService) CreateMethodValidateFn(
+	fullMethod string,
+	requestExample any,
+	auxiliaryValidatorFns ...any,
+) (ValidateFn, error) {
+	return p.createValidateFn(
+		fullMethod,
+		requestExample,
+		true,
+		auxiliaryValidatorFns...,
+	)
+}
+
+// createValidateFn creates a validate function for a given request example, which must be a protobuf message,
+// caching it by the request type and, if it has auxiliary validator functions, by the method
+//
+// Parameters:
+//
+//   - fullMethod: the full method name, empty if the validate function is not specific to a method
+//   - requestExample: an example of the request to validate
+//   - cache: whether to cache the validate function or not
+//   - auxiliaryValidatorFns: auxiliary validator functions to use in the validation
+//
+// Returns:
+//
+//   - ValidateFn: the validate function
+//   - error: if the request example is not a protobuf message
+func (p ProtovalidateService) createValidateFn(
+	fullMethod string,
+	requestExample any,
+	cache bool,
+	auxiliaryValidatorFns ...any,
+) (ValidateFn, error) {
+	// Check if the request example is a protobuf message
+	message, ok := requestExample.(proto.Message)
+	if !ok || message == nil {
+		return nil, ErrNotProtoMessage
+	}
+	requestType := string(message.ProtoReflect().Descriptor().FullName())
+
+	// Check if the validate function should be cached
+	if !cache || p.validateFns == nil {
+		return p.buildProtovalidateFn(requestType, auxiliaryValidatorFns...), nil
+	}
+	return p.validateFns.get(
+		getCacheKey(fullMethod, requestType, len(auxiliaryValidatorFns) > 0),
+		func() (ValidateFn, error) {
+			return p.buildProtovalidateFn(requestType, auxiliaryValidatorFns...), nil
+		},
+	)
+}
+
+// Validate is the function that creates (if not cached), caches and executes the validation. The validate functions
+// with auxiliary validator functions are not cached, so they are built on every call, use ValidateMethod instead
+//
+// Parameters:
+//
+//   - request: the request to validate
+//   - auxiliaryValidatorFns: auxiliary validator functions to use in the validation
+//
+// Returns:
+//
+//   - error: if there was an error validating the request
+func (p ProtovalidateService) Validate(
+	request any,
+	auxiliaryValidatorFns ...any,
+) error {
+	// Create and cache the validate function
+	validateFn, err := p.CreateValidateFn(
+		request,
+		true,
+		auxiliaryValidatorFns...,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Execute the validate function
+	return validateFn(request)
+}
+
+// ValidateMethod is the function that creates (if not cached), caches and executes the validation of the request of
+// a given method
+//
+// Parameters:
+//
+//   - fullMethod: the full method name
+//   - request: the request to validate
+//   - auxiliaryValidatorFns: auxiliary validator functions of the method to use in the validation
+//
+// Returns:
+//
+//   - error: if there was an error validating the request
+func (p ProtovalidateService) ValidateMethod(
+	fullMethod string,
+	request any,
+	auxiliaryValidatorFns ...any,
+) error {
+	// Create and cache the validate function
+	validateFn, err := p.CreateMethodValidateFn(
+		fullMethod,
+		request,
+		auxiliaryValidatorFns...,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Execute the validate function
+	return validateFn(request)
+}
+
+// Warmup precompiles and caches the validate functions of the request types of every method registered in the
+// given server, so the first requests do not pay for building them
+//
+// Parameters:
+//
+//   - serviceInfoProvider: the provider of the registered services, e.g. the *grpc.Server
+//   - auxiliaryValidatorFns: the method matcher of the auxiliary validator functions of each method (optional, can
+//     be nil)
+//
+// Returns:
+//
+//   - error: if the service info provider is nil or any validate function could not be built
+func (p ProtovalidateService) Warmup(
+	serviceInfoProvider ServiceInfoProvider,
+	auxiliaryValidatorFns *gogrpc.MethodMatcher[[]any],
+) error {
+	return warmup(
+		serviceInfoProvider,
+		auxiliaryValidatorFns,
+		p.CreateMethodValidateFn,
+		p.logger,
+	)
+}
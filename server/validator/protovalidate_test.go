@@ -0,0 +1,227 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"buf.build/go/protovalidate"
+	"connectrpc.com/connect"
+	govalidatormappervalidation "github.com/ralvarezdev/go-validator/mapper/validation"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// newTestMessageDescriptor creates the descriptor of a message with two email fields constrained by protovalidate
+func newTestMessageDescriptor(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+
+	emailField := func(name string, number int32) *descriptorpb.FieldDescriptorProto {
+		options := &descriptorpb.FieldOptions{}
+		proto.SetExtension(
+			options,
+			validate.E_Field,
+			validate.FieldRules_builder{
+				String: validate.StringRules_builder{
+					Email: proto.Bool(true),
+				}.Build(),
+			}.Build(),
+		)
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+			Options:  options,
+		}
+	}
+
+	file, err := protodesc.NewFile(
+		&descriptorpb.FileDescriptorProto{
+			Name:       proto.String("test/validator.proto"),
+			Package:    proto.String("test.validator"),
+			Syntax:     proto.String("proto3"),
+			Dependency: []string{"buf/validate/validate.proto"},
+			MessageType: []*descriptorpb.DescriptorProto{
+				{
+					Name: proto.String("Request"),
+					Field: []*descriptorpb.FieldDescriptorProto{
+						emailField("email", 1),
+						emailField("backup_email", 2),
+					},
+				},
+			},
+		},
+		protoregistry.GlobalFiles,
+	)
+	if err != nil {
+		t.Fatalf("NewFile: %v", err)
+	}
+	return file.Messages().ByName("Request")
+}
+
+// newTestRequest creates a request with the given emails
+func newTestRequest(descriptor protoreflect.MessageDescriptor, email, backupEmail string) *dynamicpb.Message {
+	request := dynamicpb.NewMessage(descriptor)
+	request.Set(descriptor.Fields().ByName("email"), protoreflect.ValueOfString(email))
+	request.Set(descriptor.Fields().ByName("backup_email"), protoreflect.ValueOfString(backupEmail))
+	return request
+}
+
+// getFieldViolations gets the field violations of a validation error
+func getFieldViolations(t *testing.T, err error) []*errdetails.BadRequest_FieldViolation {
+	t.Helper()
+
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) || connectErr.Code() != connect.CodeInvalidArgument {
+		t.Fatalf("expected an invalid argument connect error, got %v", err)
+	}
+	for _, detail := range connectErr.Details() {
+		value, valueErr := detail.Value()
+		if valueErr != nil {
+			continue
+		}
+		if badRequest, ok := value.(*errdetails.BadRequest); ok {
+			return badRequest.GetFieldViolations()
+		}
+	}
+	t.Fatal("expected a bad request detail")
+	return nil
+}
+
+func TestProtovalidateService(t *testing.T) {
+	descriptor := newTestMessageDescriptor(t)
+	tests := []struct {
+		name               string
+		options            []Option
+		email              string
+		backupEmail        string
+		expectedViolations int
+	}{
+		{
+			name:        "valid request",
+			email:       "user@example.com",
+			backupEmail: "backup@example.com",
+		},
+		{
+			name:               "invalid request",
+			email:              "user",
+			backupEmail:        "backup",
+			expectedViolations: 2,
+		},
+		{
+			name:               "invalid request with the fail fast validator option",
+			options:            []Option{WithProtovalidateOptions(protovalidate.WithFailFast())},
+			email:              "user",
+			backupEmail:        "backup",
+			expectedViolations: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				service, err := NewBackendService(
+					BackendProtovalidate,
+					nil,
+					nil,
+					"",
+					nil,
+					test.options...,
+				)
+				if err != nil {
+					t.Fatalf("NewBackendService: %v", err)
+				}
+
+				err = service.Validate(newTestRequest(descriptor, test.email, test.backupEmail))
+				if test.expectedViolations == 0 {
+					if err != nil {
+						t.Fatalf("expected no error, got %v", err)
+					}
+					return
+				}
+				if violations := getFieldViolations(t, err); len(violations) != test.expectedViolations {
+					t.Fatalf("expected %d field violations, got %v", test.expectedViolations, violations)
+				}
+			},
+		)
+	}
+}
+
+func TestProtovalidateServiceAuxiliaryValidatorFnsAreNotShared(t *testing.T) {
+	descriptor := newTestMessageDescriptor(t)
+	service, err := NewProtovalidateService(nil, nil, "", nil)
+	if err != nil {
+		t.Fatalf("NewProtovalidateService: %v", err)
+	}
+	request := newTestRequest(descriptor, "user@example.com", "backup@example.com")
+	rejectRequest := func(
+		_ *dynamicpb.Message,
+		validations *govalidatormappervalidation.StructValidations,
+	) {
+		validations.AddFieldValidationError("email", errors.New("email is rejected"))
+	}
+	acceptRequest := func(*dynamicpb.Message, *govalidatormappervalidation.StructValidations) {}
+
+	// The auxiliary validator functions of a method are not used by the other methods with the same request type
+	if err = service.ValidateMethod("/pkg.Service/Checked", request, rejectRequest); err == nil {
+		t.Fatal("expected the auxiliary validator function to reject the request")
+	}
+	if err = service.ValidateMethod("/pkg.Service/Unchecked", request); err != nil {
+		t.Fatalf("expected no error for the method without auxiliary validator functions, got %v", err)
+	}
+	if err = service.Validate(request, rejectRequest); err == nil {
+		t.Fatal("expected the auxiliary validator function to reject the request")
+	}
+	if err = service.Validate(request, acceptRequest); err != nil {
+		t.Fatalf("expected no error with other auxiliary validator functions, got %v", err)
+	}
+	if err = service.Validate(request); err != nil {
+		t.Fatalf("expected no error without auxiliary validator functions, got %v", err)
+	}
+}
+
+func TestNewBackendServiceErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		backend     Backend
+		errorFormat ErrorFormat
+		expected    error
+	}{
+		{
+			name:     "invalid backend",
+			backend:  "unknown",
+			expected: ErrInvalidBackend,
+		},
+		{
+			name:        "invalid error format of the go-validator backend",
+			backend:     BackendGoValidator,
+			errorFormat: "unknown",
+			expected:    ErrInvalidErrorFormat,
+		},
+		{
+			name:        "invalid error format of the protovalidate backend",
+			backend:     BackendProtovalidate,
+			errorFormat: "unknown",
+			expected:    ErrInvalidErrorFormat,
+		},
+	}
+	for _, test := range tests {
+		t.Run(
+			test.name, func(t *testing.T) {
+				service, err := NewBackendService(test.backend, nil, nil, test.errorFormat, nil)
+				if !errors.Is(err, test.expected) {
+					t.Fatalf("expected %v, got %v", test.expected, err)
+				}
+				if service != nil {
+					t.Fatalf("expected a nil service, got %#v", service)
+				}
+			},
+		)
+	}
+}
@@ -13,6 +13,8 @@ import (
 	govalidatormappervalidation "github.com/ralvarezdev/go-validator/mapper/validation"
 	govalidatormappervalidator "github.com/ralvarezdev/go-validator/mapper/validator"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	gogrpcstatusconnect "github.com/ralvarezdev/go-grpc/status/connect"
 )
 
 type (
@@ -21,9 +23,11 @@ type (
 	DefaultService struct {
 		generator        govalidatormapper.Generator
 		service          govalidatormappervalidator.Service
-		validateFns      map[string]ValidateFn
+		validateFns      *validateFnCache
 		birthdateOptions *govalidatormappervalidator.BirthdateOptions
 		passwordOptions  *govalidatormappervalidator.PasswordOptions
+		fieldOptions     *FieldOptions
+		errorFormat      ErrorFormat
 		logger           *slog.Logger
 	}
 )
@@ -34,17 +38,38 @@ type (
 //
 //   - birthdateOptions: the default birthdate options (optional, can be nil)
 //   - passwordOptions: the default password options (optional, can be nil)
+//   - errorFormat: the form of the returned errors, either connect errors or gRPC status errors (optional, defaults
+//     to connect errors)
 //   - logger: the logger
+//   - options: the optional settings, e.g. WithFieldOptions
 //
 // Returns:
 //
 //   - *Validator: the validator
-//   - error: if there was an error creating the validator service
+//   - error: if the error format or the field options are invalid, or there was an error creating the validator
+//     service
 func NewService(
 	birthdateOptions *govalidatormappervalidator.BirthdateOptions,
 	passwordOptions *govalidatormappervalidator.PasswordOptions,
+	errorFormat ErrorFormat,
 	logger *slog.Logger,
+	options ...Option,
 ) (*DefaultService, error) {
+	// Check the error format
+	switch errorFormat {
+	case "":
+		errorFormat = ErrorFormatConnect
+	case ErrorFormatConnect, ErrorFormatGRPC:
+	default:
+		return nil, ErrInvalidErrorFormat
+	}
+
+	// Check the field options
+	fieldOptions := newOptions(options...).FieldOptions
+	if err := checkFieldOptions(fieldOptions); err != nil {
+		return nil, err
+	}
+
 	// Initialize the raw parser
 	rawParser := govalidatormapperparser.NewDefaultRawParser(logger)
 
@@ -82,11 +107,50 @@ func NewService(
 		generator:        generator,
 		birthdateOptions: birthdateOptions,
 		passwordOptions:  passwordOptions,
+		fieldOptions:     fieldOptions,
+		errorFormat:      errorFormat,
 		logger:           logger,
-		validateFns:      make(map[string]ValidateFn),
+		validateFns:      newValidateFnCache(),
 	}, nil
 }
 
+// formatError converts a connect error to the configured error format
+//
+// Parameters:
+//
+//   - connectErr: the connect error
+//
+// Returns:
+//
+//   - error: the connect error, or its gRPC status error
+func (d DefaultService) formatError(connectErr *connect.Error) error {
+	if d.errorFormat == ErrorFormatGRPC {
+		return gogrpcstatusconnect.ToStatus(connectErr).Err()
+	}
+	return connectErr
+}
+
+// newValidationsError creates the invalid argument error of the failed validations
+//
+// Parameters:
+//
+//   - badRequest: the field violations of the failed validations
+//
+// Returns:
+//
+//   - error: the invalid argument error with the bad request detail, in the configured error format
+func (d DefaultService) newValidationsError(badRequest *errdetails.BadRequest) error {
+	// Create status with details
+	connectErr := connect.NewError(
+		connect.CodeInvalidArgument,
+		ErrValidationsFailed,
+	)
+	if detail, detailErr := connect.NewErrorDetail(badRequest); detailErr == nil {
+		connectErr.AddDetail(detail)
+	}
+	return d.formatError(connectErr)
+}
+
 // Email validates an email field
 //
 // Parameters:
@@ -163,7 +227,10 @@ func (d DefaultService) Password(
 	)
 }
 
-// CreateValidateFn creates a validate function for a given request example
+// CreateValidateFn creates a validate function for a given request example. The cached validate functions are
+// keyed by the request type and built only once, even if they are requested concurrently. The validate functions
+// with auxiliary validator functions are not cached, since those are specific to a method, use
+// CreateMethodValidateFn instead
 //
 // Parameters:
 //
@@ -180,13 +247,97 @@ func (d DefaultService) CreateValidateFn(
 	cache bool,
 	auxiliaryValidatorFns ...any,
 ) (ValidateFn, error) {
-	// Check if the validate function is already cached
-	if cache && d.validateFns != nil {
-		if validateFn, ok := d.validateFns[goreflect.UniqueTypeReference(requestExample)]; ok {
-			return validateFn, nil
-		}
+	return d.createValidateFn(
+		"",
+		requestExample,
+		cache && len(auxiliaryValidatorFns) == 0,
+		auxiliaryValidatorFns...,
+	)
+}
+
+// CreateMethodValidateFn creates and caches a validate function for the request of a given method. The validate
+// functions with auxiliary validator functions are keyed by the method and the request type, so they are not shared
+// with the other methods with the same request type
+//
+// Parameters:
+//
+//   - fullMethod: the full method name
+//   - requestExample: an example of the request to validate
+//   - auxiliaryValidatorFns: auxiliary validator functions of the method to use in the validation
+//
+// Returns:
+//
+//   - ValidateFn: the validate function
+//   - error: if there was an error creating the validate function
+func (d DefaultService) CreateMethodValidateFn(
+	fullMethod string,
+	requestExample any,
+	auxiliaryValidatorFns ...any,
+) (ValidateFn, error) {
+	return d.createValidateFn(
+		fullMethod,
+		requestExample,
+		true,
+		auxiliaryValidatorFns...,
+	)
+}
+
+// createValidateFn creates a validate function for a given request example, caching it by the request type and, if
+// it has auxiliary validator functions, by the method
+//
+// Parameters:
+//
+//   - fullMethod: the full method name, empty if the validate function is not specific to a method
+//   - requestExample: an example of the request to validate
+//   - cache: whether to cache the validate function or not
+//   - auxiliaryValidatorFns: auxiliary validator functions to use in the validation
+//
+// Returns:
+//
+//   - ValidateFn: the validate function
+//   - error: if there was an error creating the validate function
+func (d DefaultService) createValidateFn(
+	fullMethod string,
+	requestExample any,
+	cache bool,
+	auxiliaryValidatorFns ...any,
+) (ValidateFn, error) {
+	// Check if the request example is nil
+	if requestExample == nil {
+		return nil, ErrNilRequest
 	}
 
+	// Check if the validate function should be cached
+	if !cache || d.validateFns == nil {
+		return d.buildValidateFn(requestExample, auxiliaryValidatorFns...)
+	}
+	return d.validateFns.get(
+		getCacheKey(
+			fullMethod,
+			goreflect.UniqueTypeReference(requestExample),
+			len(auxiliaryValidatorFns) > 0,
+		),
+		func() (ValidateFn, error) {
+			return d.buildValidateFn(requestExample, auxiliaryValidatorFns...)
+		},
+	)
+}
+
+// buildValidateFn builds a validate function for a given request example
+//
+// Parameters:
+//
+//   - requestExample: an example of the request to validate
+//   - auxiliaryValidatorFns: auxiliary validator functions to use in the validation
+//
+// Returns:
+//
+//   - ValidateFn: the validate function
+//   - error: if there was an error building the validate function
+func (d DefaultService) buildValidateFn(
+	requestExample any,
+	auxiliaryValidatorFns ...any,
+) (ValidateFn, error) {
 	// Get the type of the request
 	requestType := goreflect.GetDereferencedType(requestExample)
 
@@ -200,16 +351,18 @@ func (d DefaultService) CreateValidateFn(
 				slog.Any("error", err),
 			)
 		}
-		return nil, connect.NewError(
-			connect.CodeInternal,
-			fmt.Errorf(ErrFailedToCreateMapper, requestType.String()),
+		return nil, d.formatError(
+			connect.NewError(
+				connect.CodeInternal,
+				fmt.Errorf(ErrFailedToCreateMapper, requestType.String()),
+			),
 		)
 	}
 
-	// Create the inner validate function
+	// Create the inner validate function, without caching it since its cache is not concurrency-safe
 	innerValidateFn, err := d.service.CreateValidateFn(
 		mapper,
-		cache,
+		false,
 		auxiliaryValidatorFns...,
 	)
 	if err != nil {
@@ -220,9 +373,11 @@ func (d DefaultService) CreateValidateFn(
 				slog.Any("error", err),
 			)
 		}
-		return nil, connect.NewError(
-			connect.CodeInternal,
-			fmt.Errorf(ErrFailedToCreateValidateFunction, requestType.String()),
+		return nil, d.formatError(
+			connect.NewError(
+				connect.CodeInternal,
+				fmt.Errorf(ErrFailedToCreateValidateFunction, requestType.String()),
+			),
 		)
 	}
 
@@ -238,9 +393,11 @@ func (d DefaultService) CreateValidateFn(
 					slog.Any("error", innerErr),
 				)
 			}
-			return connect.NewError(
-				connect.CodeInternal,
-				fmt.Errorf(ErrFailedToValidateRequest, requestType.String()),
+			return d.formatError(
+				connect.NewError(
+					connect.CodeInternal,
+					fmt.Errorf(ErrFailedToValidateRequest, requestType.String()),
+				),
 			)
 		}
 
@@ -259,34 +416,22 @@ func (d DefaultService) CreateValidateFn(
 					slog.Any("validations", validations),
 				)
 			}
-			return connect.NewError(
-				connect.CodeInternal,
-				fmt.Errorf(ErrFailedToAssertValidationsToBadRequest, requestType.String()),
+			return d.formatError(
+				connect.NewError(
+					connect.CodeInternal,
+					fmt.Errorf(ErrFailedToAssertValidationsToBadRequest, requestType.String()),
+				),
 			)
 		}
 
-		// Create status with details
-		connectErr := connect.NewError(
-			connect.CodeInvalidArgument,
-			ErrValidationsFailed,
-		)
-		if detail, detailErr := connect.NewErrorDetail(badRequest); detailErr == nil {
-			connectErr.AddDetail(detail)
-		}
-		return connectErr
+		return d.newValidationsError(badRequest)
 	}
 
-	// Cache the validate function
-	if cache {
-		if d.validateFns == nil {
-			d.validateFns = make(map[string]ValidateFn)
-		}
-		d.validateFns[goreflect.UniqueTypeReference(mapper.GetStructInstance())] = validateFn
-	}
 	return validateFn, nil
 }
 
-// Validate is the function that creates (if not cached), caches and executes the validation
+// Validate is the function that creates (if not cached), caches and executes the validation. The validate functions
+// with auxiliary validator functions are not cached, so they are built on every call, use ValidateMethod instead
 //
 // Parameters:
 //
@@ -313,3 +458,34 @@ func (d DefaultService) Validate(
 	// Execute the validate function
 	return validateFn(request)
 }
+
+// ValidateMethod is the function that creates (if not cached), caches and executes the validation of the request of
+// a given method
+//
+// Parameters:
+//
+//   - fullMethod: the full method name
+//   - request: the request to validate
+//   - auxiliaryValidatorFns: auxiliary validator functions of the method to use in the validation
+//
+// Returns:
+//
+//   - error: if there was an error validating the request
+func (d DefaultService) ValidateMethod(
+	fullMethod string,
+	request any,
+	auxiliaryValidatorFns ...any,
+) error {
+	// Create and cache the validate function
+	validateFn, err := d.CreateMethodValidateFn(
+		fullMethod,
+		request,
+		auxiliaryValidatorFns...,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Execute the validate function
+	return validateFn(request)
+}
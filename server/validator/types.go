@@ -1,6 +1,113 @@
 package validator
 
+import (
+	"time"
+
+	"buf.build/go/protovalidate"
+)
+
 type (
 	// ValidateFn func type for validating a value
 	ValidateFn func(request any) error
+
+	// ErrorFormat is the form of the errors returned by the validator service
+	ErrorFormat string
+
+	// Backend is the validation backend of the validator service
+	Backend string
+
+	// Options are the optional settings of the validator services
+	Options struct {
+		// FieldOptions are the default options of the field validators of the FieldService
+		FieldOptions *FieldOptions
+
+		// ProtovalidateOptions are the protovalidate validator options, e.g. the CEL and custom rule options, used by
+		// the protovalidate backend
+		ProtovalidateOptions []protovalidate.ValidatorOption
+	}
+
+	// Option sets an optional setting of the validator services
+	Option func(options *Options)
+
+	// IPVersion is the IP version accepted by the IP and CIDR validators
+	IPVersion int
+
+	// PhoneNumberOptions are the options of the E.164 phone number validator
+	PhoneNumberOptions struct {
+		// AllowedCallingCodes are the allowed country calling codes without the plus sign, e.g. "1" or "34", which
+		// must be in CallingCodes. If empty every calling code is allowed
+		AllowedCallingCodes []string
+	}
+
+	// URLOptions are the options of the URL validator
+	URLOptions struct {
+		// AllowedSchemes are the allowed URL schemes, if empty only http and https are allowed
+		AllowedSchemes []string
+
+		// AllowedHosts are the allowed URL hosts, without the port. If empty every host is allowed
+		AllowedHosts []string
+	}
+
+	// UUIDOptions are the options of the UUID validator
+	UUIDOptions struct {
+		// Versions are the allowed UUID versions, if empty every version is allowed
+		Versions []int
+	}
+
+	// IPOptions are the options of the IP and CIDR validators
+	IPOptions struct {
+		// Version is the allowed IP version, if zero both IPv4 and IPv6 are allowed
+		Version IPVersion
+
+		// DenyPrivate rejects the private addresses
+		DenyPrivate bool
+
+		// DenyLoopback rejects the loopback addresses
+		DenyLoopback bool
+	}
+
+	// CountryCodeOptions are the options of the ISO 3166-1 alpha-2 country code validator
+	CountryCodeOptions struct {
+		// AllowedCodes are the allowed country codes, if empty every country code is allowed
+		AllowedCodes []string
+	}
+
+	// CurrencyCodeOptions are the options of the ISO 4217 currency code validator
+	CurrencyCodeOptions struct {
+		// AllowedCodes are the allowed currency codes, if empty every currency code is allowed
+		AllowedCodes []string
+	}
+
+	// SlugOptions are the options of the slug validator
+	SlugOptions struct {
+		// MinimumLength is the minimum length of the slug, if zero there is no minimum
+		MinimumLength int
+
+		// MaximumLength is the maximum length of the slug, if zero there is no maximum
+		MaximumLength int
+	}
+
+	// TimeRangeOptions are the options of the time range validator
+	TimeRangeOptions struct {
+		// AllowEqual allows the start and the end of the range to be equal
+		AllowEqual bool
+
+		// MinimumDuration is the minimum duration of the range, if zero there is no minimum
+		MinimumDuration time.Duration
+
+		// MaximumDuration is the maximum duration of the range, if zero there is no maximum
+		MaximumDuration time.Duration
+	}
+
+	// FieldOptions are the default options of the field validators
+	FieldOptions struct {
+		PhoneNumber  *PhoneNumberOptions
+		URL          *URLOptions
+		UUID         *UUIDOptions
+		IP           *IPOptions
+		CountryCode  *CountryCodeOptions
+		CurrencyCode *CurrencyCodeOptions
+		Slug         *SlugOptions
+		TimeRange    *TimeRangeOptions
+	}
 )
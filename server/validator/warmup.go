@@ -0,0 +1,128 @@
+package validator
+
+import (
+	"errors"
+	"log/slog"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	gogrpc "github.com/ralvarezdev/go-grpc"
+)
+
+// warmup precompiles and caches the validate functions of the request types of every method registered in the
+// given server. The request types are resolved with the global protobuf registry
+//
+// Parameters:
+//
+//   - serviceInfoProvider: the provider of the registered services, e.g. the *grpc.Server
+//   - auxiliaryValidatorFns: the method matcher of the auxiliary validator functions of each method (optional, can
+//     be nil)
+//   - createMethodValidateFn: the function that creates and caches the validate function of the request of a method
+//   - logger: the logger to use (optional, can be nil)
+//
+// Returns:
+//
+//   - error: if the service info provider is nil or any validate function could not be built
+func warmup(
+	serviceInfoProvider ServiceInfoProvider,
+	auxiliaryValidatorFns *gogrpc.MethodMatcher[[]any],
+	createMethodValidateFn func(
+		fullMethod string,
+		requestExample any,
+		auxiliaryValidatorFns ...any,
+	) (ValidateFn, error),
+	logger *slog.Logger,
+) error {
+	// Check if the service info provider is nil
+	if serviceInfoProvider == nil {
+		return ErrNilServiceInfoProvider
+	}
+
+	var errs []error
+	warmedUp := 0
+	for serviceName, serviceInfo := range serviceInfoProvider.GetServiceInfo() {
+		// Get the service descriptor
+		descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(
+			protoreflect.FullName(serviceName),
+		)
+		if err != nil {
+			if logger != nil {
+				logger.Warn(
+					"Skipped service not found in the protobuf registry",
+					slog.String("service", serviceName),
+					slog.String("error", err.Error()),
+				)
+			}
+			continue
+		}
+		serviceDescriptor, ok := descriptor.(protoreflect.ServiceDescriptor)
+		if !ok {
+			continue
+		}
+
+		for _, methodInfo := range serviceInfo.Methods {
+			methodDescriptor := serviceDescriptor.Methods().ByName(
+				protoreflect.Name(methodInfo.Name),
+			)
+			if methodDescriptor == nil {
+				continue
+			}
+
+			// Get the request type
+			messageType, findErr := protoregistry.GlobalTypes.FindMessageByName(
+				methodDescriptor.Input().FullName(),
+			)
+			if findErr != nil {
+				errs = append(errs, findErr)
+				continue
+			}
+
+			// Build and cache the validate function of the method, keyed as the interceptor looks it up
+			fullMethod := "/" + serviceName + "/" + methodInfo.Name
+			methodAuxiliaryValidatorFns, _ := auxiliaryValidatorFns.Match(fullMethod)
+			if _, err = createMethodValidateFn(
+				fullMethod,
+				messageType.New().Interface(),
+				methodAuxiliaryValidatorFns...,
+			); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			warmedUp++
+		}
+	}
+
+	if logger != nil {
+		logger.Info(
+			"Validate functions warmed up",
+			slog.Int("methods", warmedUp),
+		)
+	}
+	return errors.Join(errs...)
+}
+
+// Warmup precompiles and caches the validate functions of the request types of every method registered in the
+// given server, so the first requests do not pay for building them. The request types are resolved with the global
+// protobuf registry
+//
+// Parameters:
+//
+//   - serviceInfoProvider: the provider of the registered services, e.g. the *grpc.Server
+//   - auxiliaryValidatorFns: the method matcher of the auxiliary validator functions of each method (optional, can
+//     be nil)
+//
+// Returns:
+//
+//   - error: if the service info provider is nil or any validate function could not be built
+func (d DefaultService) Warmup(
+	serviceInfoProvider ServiceInfoProvider,
+	auxiliaryValidatorFns *gogrpc.MethodMatcher[[]any],
+) error {
+	return warmup(
+		serviceInfoProvider,
+		auxiliaryValidatorFns,
+		d.CreateMethodValidateFn,
+		d.logger,
+	)
+}
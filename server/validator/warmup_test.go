@@ -0,0 +1,113 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+
+	govalidatormappervalidation "github.com/ralvarezdev/go-validator/mapper/validation"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	gogrpc "github.com/ralvarezdev/go-grpc"
+)
+
+type (
+	// stubServiceInfoProvider provides the health service, whose methods share their request type
+	stubServiceInfoProvider struct{}
+)
+
+func (stubServiceInfoProvider) GetServiceInfo() map[string]grpc.ServiceInfo {
+	return map[string]grpc.ServiceInfo{
+		grpc_health_v1.Health_ServiceDesc.ServiceName: {
+			Methods: []grpc.MethodInfo{
+				{Name: "Check"},
+				{Name: "Watch", IsServerStream: true},
+			},
+		},
+		"pkg.Unknown": {
+			Methods: []grpc.MethodInfo{{Name: "Method"}},
+		},
+	}
+}
+
+// rejectService is an auxiliary validator function that rejects every service name
+func rejectService(
+	_ *grpc_health_v1.HealthCheckRequest,
+	validations *govalidatormappervalidation.StructValidations,
+) {
+	validations.AddFieldValidationError("service", errors.New("service is rejected"))
+}
+
+func TestWarmup(t *testing.T) {
+	auxiliaryValidatorFns, err := gogrpc.NewMethodMatcher(
+		map[string][]any{
+			grpc_health_v1.Health_Check_FullMethodName: {rejectService},
+		},
+	)
+	if err != nil {
+		t.Fatalf("NewMethodMatcher: %v", err)
+	}
+
+	for _, backend := range []Backend{BackendGoValidator, BackendProtovalidate} {
+		t.Run(
+			string(backend), func(t *testing.T) {
+				service, err := NewBackendService(backend, nil, nil, ErrorFormatGRPC, nil)
+				if err != nil {
+					t.Fatalf("NewBackendService: %v", err)
+				}
+				if err = service.Warmup(stubServiceInfoProvider{}, auxiliaryValidatorFns); err != nil {
+					t.Fatalf("Warmup: %v", err)
+				}
+
+				// The warmed up validate functions of each method keep their own auxiliary validator functions
+				request := &grpc_health_v1.HealthCheckRequest{Service: "service"}
+				err = service.ValidateMethod(
+					grpc_health_v1.Health_Check_FullMethodName,
+					request,
+					rejectService,
+				)
+				if status.Code(err) != codes.InvalidArgument {
+					t.Fatalf("expected InvalidArgument for the Check method, got %v", err)
+				}
+				if err = service.ValidateMethod(grpc_health_v1.Health_Watch_FullMethodName, request); err != nil {
+					t.Fatalf("expected no error for the Watch method, got %v", err)
+				}
+			},
+		)
+	}
+}
+
+func TestWarmupCacheKeys(t *testing.T) {
+	auxiliaryValidatorFns, err := gogrpc.NewMethodMatcher(
+		map[string][]any{
+			grpc_health_v1.Health_Check_FullMethodName: {rejectService},
+		},
+	)
+	if err != nil {
+		t.Fatalf("NewMethodMatcher: %v", err)
+	}
+	service, err := NewService(nil, nil, ErrorFormatGRPC, nil)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	if err = service.Warmup(stubServiceInfoProvider{}, auxiliaryValidatorFns); err != nil {
+		t.Fatalf("Warmup: %v", err)
+	}
+
+	// The Check method is cached apart from the request type, which is shared with the Watch method
+	if length := len(service.validateFns.validateFns); length != 2 {
+		t.Fatalf("expected 2 cached validate functions, got %d", length)
+	}
+}
+
+func TestWarmupNilServiceInfoProvider(t *testing.T) {
+	service, err := NewService(nil, nil, ErrorFormatGRPC, nil)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	if err = service.Warmup(nil, nil); !errors.Is(err, ErrNilServiceInfoProvider) {
+		t.Fatalf("expected ErrNilServiceInfoProvider, got %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package connect
+
+import (
+	"errors"
+
+	"connectrpc.com/connect"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// typeURLPrefix is the prefix of the type URLs of the details packed into Any messages
+const typeURLPrefix = "type.googleapis.com/"
+
+// ToStatus converts a connect error to a gRPC status, keeping its code, message and details
+//
+// Parameters:
+//
+//   - connectErr: the connect error to convert
+//
+// Returns:
+//
+//   - *status.Status: the gRPC status, or nil if the connect error is nil
+func ToStatus(connectErr *connect.Error) *status.Status {
+	if connectErr == nil {
+		return nil
+	}
+
+	// Pack the details, the connect codes share the gRPC codes numbering
+	details := make([]*anypb.Any, 0, len(connectErr.Details()))
+	for _, detail := range connectErr.Details() {
+		details = append(
+			details, &anypb.Any{
+				TypeUrl: typeURLPrefix + detail.Type(),
+				Value:   detail.Bytes(),
+			},
+		)
+	}
+	return status.FromProto(
+		&spb.Status{
+			Code:    int32(connectErr.Code()),
+			Message: connectErr.Message(),
+			Details: details,
+		},
+	)
+}
+
+// FromStatus converts a gRPC status to a connect error, keeping its code, message and details
+//
+// Parameters:
+//
+//   - st: the gRPC status to convert
+//
+// Returns:
+//
+//   - *connect.Error: the connect error, or nil if the status is nil or its code is OK
+func FromStatus(st *status.Status) *connect.Error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+
+	// Add the details, the gRPC codes share the connect codes numbering
+	connectErr := connect.NewError(
+		connect.Code(st.Code()),
+		errors.New(st.Message()),
+	)
+	for _, detail := range st.Proto().GetDetails() {
+		if connectDetail, err := connect.NewErrorDetail(detail); err == nil {
+			connectErr.AddDetail(connectDetail)
+		}
+	}
+	return connectErr
+}
+
+// ToStatusError converts an error to a gRPC status error if it is, or wraps, a connect error
+//
+// Parameters:
+//
+//   - err: the error to convert
+//
+// Returns:
+//
+//   - error: the gRPC status error, or the given error if it is not a connect error
+func ToStatusError(err error) error {
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) {
+		return err
+	}
+	return ToStatus(connectErr).Err()
+}
+
+// ToConnectError converts an error to a connect error if it is a gRPC status error
+//
+// Parameters:
+//
+//   - err: the error to convert
+//
+// Returns:
+//
+//   - error: the connect error, or the given error if it is not a gRPC status error
+func ToConnectError(err error) error {
+	// Check if the error is already a connect error
+	var connectErr *connect.Error
+	if err == nil || errors.As(err, &connectErr) {
+		return err
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	if connectErr = FromStatus(st); connectErr == nil {
+		return nil
+	}
+	return connectErr
+}
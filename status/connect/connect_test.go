@@ -0,0 +1,131 @@
+package connect
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"connectrpc.com/connect"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// newTestStatus creates a gRPC status with the given code and message, and a bad request detail
+func newTestStatus(t *testing.T, code codes.Code, message string) *status.Status {
+	t.Helper()
+
+	st, err := status.New(code, message).WithDetails(
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "name", Description: "cannot be empty"},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("WithDetails: %v", err)
+	}
+	return st
+}
+
+// checkBadRequest checks the status has the bad request detail of newTestStatus
+func checkBadRequest(t *testing.T, st *status.Status) {
+	t.Helper()
+
+	details := st.Details()
+	if len(details) != 1 {
+		t.Fatalf("expected a single detail, got %v", details)
+	}
+	badRequest, ok := details[0].(*errdetails.BadRequest)
+	if !ok {
+		t.Fatalf("expected a bad request detail, got %T", details[0])
+	}
+	expected := &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "name", Description: "cannot be empty"},
+		},
+	}
+	if !proto.Equal(badRequest, expected) {
+		t.Fatalf("expected %v, got %v", expected, badRequest)
+	}
+}
+
+func TestStatusRoundTrip(t *testing.T) {
+	for _, code := range []codes.Code{
+		codes.InvalidArgument,
+		codes.NotFound,
+		codes.PermissionDenied,
+		codes.Unauthenticated,
+		codes.ResourceExhausted,
+		codes.Internal,
+		codes.Unavailable,
+	} {
+		t.Run(
+			code.String(), func(t *testing.T) {
+				connectErr := FromStatus(newTestStatus(t, code, "request failed"))
+				if connectErr.Code() != connect.Code(code) || connectErr.Message() != "request failed" {
+					t.Fatalf("expected (%v, %q), got (%v, %q)", code, "request failed", connectErr.Code(), connectErr.Message())
+				}
+
+				st := ToStatus(connectErr)
+				if st.Code() != code || st.Message() != "request failed" {
+					t.Fatalf("expected (%v, %q), got (%v, %q)", code, "request failed", st.Code(), st.Message())
+				}
+				checkBadRequest(t, st)
+			},
+		)
+	}
+}
+
+func TestErrorRoundTrip(t *testing.T) {
+	connectErr := ToConnectError(newTestStatus(t, codes.InvalidArgument, "invalid request").Err())
+	err := ToStatusError(fmt.Errorf("wrapped: %w", connectErr))
+
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("expected a gRPC status error, got %v", err)
+	}
+	if st.Code() != codes.InvalidArgument || st.Message() != "invalid request" {
+		t.Fatalf("expected (%v, %q), got (%v, %q)", codes.InvalidArgument, "invalid request", st.Code(), st.Message())
+	}
+	checkBadRequest(t, st)
+}
+
+func TestNilConversions(t *testing.T) {
+	if st := ToStatus(nil); st != nil {
+		t.Fatalf("expected a nil status, got %v", st)
+	}
+	if connectErr := FromStatus(nil); connectErr != nil {
+		t.Fatalf("expected a nil connect error, got %v", connectErr)
+	}
+	if connectErr := FromStatus(status.New(codes.OK, "")); connectErr != nil {
+		t.Fatalf("expected a nil connect error for the OK status, got %v", connectErr)
+	}
+	if err := ToConnectError(nil); err != nil {
+		t.Fatalf("expected a nil error, got %v", err)
+	}
+	if err := ToStatusError(nil); err != nil {
+		t.Fatalf("expected a nil error, got %v", err)
+	}
+}
+
+func TestErrorsPassThrough(t *testing.T) {
+	plainErr := errors.New("plain error")
+	if err := ToStatusError(plainErr); err != plainErr {
+		t.Fatalf("expected the non-connect error to pass through, got %v", err)
+	}
+	if err := ToConnectError(plainErr); err != plainErr {
+		t.Fatalf("expected the non-status error to pass through, got %v", err)
+	}
+
+	statusErr := status.Error(codes.NotFound, "not found")
+	if err := ToStatusError(statusErr); err != statusErr {
+		t.Fatalf("expected the status error to pass through, got %v", err)
+	}
+
+	connectErr := connect.NewError(connect.CodeNotFound, errors.New("not found"))
+	if err := ToConnectError(connectErr); err != connectErr {
+		t.Fatalf("expected the connect error to pass through, got %v", err)
+	}
+}
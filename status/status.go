@@ -8,9 +8,10 @@ import (
 	"google.golang.org/grpc/status"
 
 	gogrpc "github.com/ralvarezdev/go-grpc"
+	gogrpcstatusconnect "github.com/ralvarezdev/go-grpc/status/connect"
 )
 
-// ExtractErrorFromStatus extracts the error from the status
+// ExtractErrorFromStatus extracts the error from the status, converting the connect errors to gRPC status errors
 //
 // Parameters:
 //
@@ -25,7 +26,7 @@ func ExtractErrorFromStatus(mode *goflagsmode.Flag, err error) (
 	codes.Code,
 	error,
 ) {
-	st, ok := status.FromError(err)
+	st, ok := status.FromError(gogrpcstatusconnect.ToStatusError(err))
 
 	// Check if the error is a status error
 	if !ok {
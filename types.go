@@ -2,13 +2,16 @@ package gogrpc
 
 import (
 	"log/slog"
+	"time"
 
 	goreflect "github.com/ralvarezdev/go-reflect"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 type (
-	// DefaultErrorDetailsGenerator is the default implementation of ErrorDetailsGenerator
+	// DefaultErrorDetailsGenerator is the default implementation of ErrorDetailsGenerator, DebugDetailsGenerator,
+	// ErrorInfoGenerator, QuotaFailureGenerator and RetryInfoGenerator
 	DefaultErrorDetailsGenerator struct {
 		logger *slog.Logger
 	}
@@ -143,3 +146,137 @@ func (d DefaultErrorDetailsGenerator) NewStructSingleFieldBadRequest(
 
 	return d.NewSingleBadRequest(field, description)
 }
+
+// NewErrorInfo creates a new error info
+//
+// Parameters:
+//
+//   - reason: the reason of the error
+//   - domain: the logical grouping to which the reason belongs
+//   - metadata: additional structured details about the error (optional, can be nil)
+//
+// Returns:
+//
+//   - *errdetails.ErrorInfo: the created error info
+func (d DefaultErrorDetailsGenerator) NewErrorInfo(
+	reason, domain string,
+	metadata map[string]string,
+) *errdetails.ErrorInfo {
+	return &errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   domain,
+		Metadata: metadata,
+	}
+}
+
+// NewRetryInfo creates a new retry info
+//
+// Parameters:
+//
+//   - retryDelay: the delay the client should wait before retrying
+//
+// Returns:
+//
+//   - *errdetails.RetryInfo: the created retry info
+func (d DefaultErrorDetailsGenerator) NewRetryInfo(
+	retryDelay time.Duration,
+) *errdetails.RetryInfo {
+	return &errdetails.RetryInfo{
+		RetryDelay: durationpb.New(retryDelay),
+	}
+}
+
+// NewQuotaViolation creates a new quota violation
+//
+// Parameters:
+//
+//   - subject: the subject on which the quota check failed
+//   - description: a description of the violation
+//
+// Returns:
+//
+//   - *errdetails.QuotaFailure_Violation: the created quota violation
+func (d DefaultErrorDetailsGenerator) NewQuotaViolation(
+	subject, description string,
+) *errdetails.QuotaFailure_Violation {
+	return &errdetails.QuotaFailure_Violation{
+		Subject:     subject,
+		Description: description,
+	}
+}
+
+// NewQuotaFailure creates a new quota failure with the given violations
+//
+// Parameters:
+//
+//   - violations: the quota violations
+//
+// Returns:
+//
+//   - *errdetails.QuotaFailure: the created quota failure
+func (d DefaultErrorDetailsGenerator) NewQuotaFailure(
+	violations []*errdetails.QuotaFailure_Violation,
+) *errdetails.QuotaFailure {
+	return &errdetails.QuotaFailure{
+		Violations: violations,
+	}
+}
+
+// NewSingleQuotaFailure creates a new quota failure with a single violation
+//
+// Parameters:
+//
+//   - subject: the subject on which the quota check failed
+//   - description: a description of the violation
+//
+// Returns:
+//
+//   - *errdetails.QuotaFailure: the created quota failure
+func (d DefaultErrorDetailsGenerator) NewSingleQuotaFailure(
+	subject, description string,
+) *errdetails.QuotaFailure {
+	return d.NewQuotaFailure(
+		[]*errdetails.QuotaFailure_Violation{
+			d.NewQuotaViolation(subject, description),
+		},
+	)
+}
+
+// NewRequestInfo creates a new request info
+//
+// Parameters:
+//
+//   - requestID: the opaque ID of the request, used to correlate it with the logs
+//   - servingData: any data used to serve the request (optional, can be empty)
+//
+// Returns:
+//
+//   - *errdetails.RequestInfo: the created request info
+func (d DefaultErrorDetailsGenerator) NewRequestInfo(
+	requestID, servingData string,
+) *errdetails.RequestInfo {
+	return &errdetails.RequestInfo{
+		RequestId:   requestID,
+		ServingData: servingData,
+	}
+}
+
+// NewDebugInfo creates a new debug info
+//
+// Parameters:
+//
+//   - stackEntries: the stack trace entries
+//   - detail: additional debugging information
+//
+// Returns:
+//
+//   - *errdetails.DebugInfo: the created debug info
+func (d DefaultErrorDetailsGenerator) NewDebugInfo(
+	stackEntries []string,
+	detail string,
+) *errdetails.DebugInfo {
+	return &errdetails.DebugInfo{
+		StackEntries: stackEntries,
+		Detail:       detail,
+	}
+}